@@ -0,0 +1,57 @@
+package main
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// backoff computes the delay before each retry of an operation: Base
+// multiplied by Multiplier once per earlier attempt, capped at Max. With
+// Jitter the delay is instead drawn uniformly between zero and that value,
+// so callers that failed together do not retry together. It is safe for
+// concurrent use.
+type backoff struct {
+	Base       time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     bool
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// Seed makes the jitter drawn from then on reproducible. Unseeded, it comes
+// from the shared source of math/rand/v2.
+func (b *backoff) Seed(seed int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rand = rand.New(rand.NewPCG(uint64(seed), 0))
+}
+
+// Delay returns the delay before retry attempt, counted from zero. A
+// Multiplier below 1 is taken as 2.
+func (b *backoff) Delay(attempt int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := b.Max
+	if d := float64(b.Base) * math.Pow(multiplier, float64(attempt)); d < float64(b.Max) {
+		delay = time.Duration(d)
+	}
+	if !b.Jitter || delay <= 0 {
+		return delay
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rand == nil {
+		return time.Duration(rand.Int64N(int64(delay) + 1))
+	}
+	return time.Duration(b.rand.Int64N(int64(delay) + 1))
+}
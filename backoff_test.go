@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelayGrowsExponentially(t *testing.T) {
+	b := &backoff{Base: 100 * time.Millisecond, Max: time.Minute, Multiplier: 3}
+
+	want := []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, 2700 * time.Millisecond}
+	for attempt, delay := range want {
+		if got := b.Delay(attempt); got != delay {
+			t.Errorf("Delay(%d) = %s, want %s", attempt, got, delay)
+		}
+	}
+}
+
+func TestBackoffDelayDefaultsMultiplier(t *testing.T) {
+	b := &backoff{Base: time.Second, Max: time.Minute}
+
+	if got := b.Delay(3); got != 8*time.Second {
+		t.Errorf("Delay(3) = %s, want 8s", got)
+	}
+}
+
+func TestBackoffDelayIsCappedAtMax(t *testing.T) {
+	b := &backoff{Base: 500 * time.Millisecond, Max: 30 * time.Second, Multiplier: 2}
+
+	for _, attempt := range []int{6, 7, 64, 2000} {
+		if got := b.Delay(attempt); got != 30*time.Second {
+			t.Errorf("Delay(%d) = %s, want 30s", attempt, got)
+		}
+	}
+}
+
+func TestBackoffJitterStaysInRange(t *testing.T) {
+	b := &backoff{Base: time.Second, Max: 10 * time.Second, Multiplier: 2, Jitter: true}
+	b.Seed(1)
+
+	for attempt := 0; attempt < 6; attempt++ {
+		limit := min(time.Second<<attempt, 10*time.Second)
+		for i := 0; i < 100; i++ {
+			if got := b.Delay(attempt); got < 0 || got > limit {
+				t.Fatalf("Delay(%d) = %s, want within [0, %s]", attempt, got, limit)
+			}
+		}
+	}
+}
+
+func TestBackoffSeedReproducesDelays(t *testing.T) {
+	draw := func(seed int64) []time.Duration {
+		b := &backoff{Base: time.Second, Max: time.Minute, Multiplier: 2, Jitter: true}
+		b.Seed(seed)
+
+		delays := make([]time.Duration, 10)
+		for i := range delays {
+			delays[i] = b.Delay(i)
+		}
+		return delays
+	}
+
+	first, second, other := draw(42), draw(42), draw(43)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("seed 42 drew %s then %s for attempt %d", first[i], second[i], i)
+		}
+	}
+
+	same := true
+	for i := range first {
+		same = same && first[i] == other[i]
+	}
+	if same {
+		t.Errorf("seeds 42 and 43 drew the same delays %v", first)
+	}
+}
+
+func TestWithRetrySeedSeedsClientBackoff(t *testing.T) {
+	a := NewClient("store", "token", WithRetryJitter(), WithRetrySeed(7))
+	b := NewClient("store", "token", WithRetryJitter(), WithRetrySeed(7))
+
+	for attempt := 0; attempt < 5; attempt++ {
+		if got, want := a.retryDelay(nil, attempt), b.retryDelay(nil, attempt); got != want {
+			t.Errorf("attempt %d delayed %s and %s with the same seed", attempt, got, want)
+		}
+	}
+}
@@ -1,20 +1,36 @@
+//go:generate go run ./tools/contextfree
+
 package main
 
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
+	"math"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 )
 
 const (
 	defaultBaseURL = "https://api.bigcommerce.com/stores/"
 	apiVersion     = "v3"
+	apiVersionV2   = "v2"
 	userAgent      = "bigcommerce-go-sdk/1.0"
 )
 
@@ -27,6 +43,21 @@ type Client struct {
 	authToken string
 
 	userAgent string
+	timeout   time.Duration
+
+	maxRetries int
+	backoff    *backoff
+	limiter    *rateLimiter
+
+	logger  *log.Logger
+	logURLs bool
+	tracer  Tracer
+
+	metrics MetricsObserver
+
+	cache ResponseCache
+
+	strictDecoding bool
 
 	Products                  *ProductsService
 	Categories                *CategoriesService
@@ -49,12 +80,128 @@ type Client struct {
 	Pricing                   *PricingService
 	Inventory                 *InventoryService
 	BulkPricingRules          *BulkPricingRulesService
+	Webhooks                  *WebhooksService
+	TaxClasses                *TaxClassesService
+	GiftWrapping              *GiftWrappingService
+	Carts                     *CartsService
+	BlogPosts                 *BlogPostsService
+	PriceListRecords          *PriceListRecordsService
+	CustomerAttributes        *CustomerAttributesService
+}
+
+type ClientOption func(*Client)
+
+func WithLogger(logger *log.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithURLLogging makes the logger from WithLogger record each request's
+// full URL, query string included, instead of only its path. Headers, and
+// so the auth token, are never logged.
+func WithURLLogging() ClientOption {
+	return func(c *Client) {
+		c.logURLs = true
+	}
+}
+
+// WithStrictDecoding makes decoding fail on response fields the SDK does not
+// model, instead of dropping them, so tests can catch API drift. Streamed
+// product lists are still decoded leniently.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+func WithTracer(tracer Tracer) ClientOption {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+// WithResponseCache enables conditional GETs: responses carrying an ETag
+// are stored in cache, and a 304 on a later request is answered from it.
+func WithResponseCache(cache ResponseCache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+func WithMetricsObserver(observer MetricsObserver) ClientOption {
+	return func(c *Client) {
+		if observer == nil {
+			observer = noopMetricsObserver{}
+		}
+		c.metrics = observer
+	}
+}
+
+// WithRetry retries requests that were rate limited or failed at a gateway
+// (429, 502, 503 or 504) or never got a response, up to maxRetries times. A
+// 429 is retried once the window in X-Rate-Limit-Time-Reset-Ms has passed,
+// anything else after an exponential backoff. The store ignores
+// Idempotency-Key, and a gateway error or lost response can follow a create
+// that went through, so POSTs are only retried after a 429 or a refused
+// connection, when the store cannot have acted on them.
+func WithRetry(maxRetries int) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithRetryJitter draws each WithRetry backoff at random up to its
+// exponential value, so goroutines sharing the client that failed together
+// do not all retry at once.
+func WithRetryJitter() ClientOption {
+	return func(c *Client) {
+		c.backoff.Jitter = true
+	}
 }
 
-func NewClient(storeHash, authToken string) *Client {
-	httpClient := &http.Client{
-		Timeout: time.Second * 30,
+// WithRetrySeed makes the jitter of WithRetryJitter reproducible, for tests
+// that assert on retry timing.
+func WithRetrySeed(seed int64) ClientOption {
+	return func(c *Client) {
+		c.backoff.Seed(seed)
 	}
+}
+
+// WithRateLimit spaces requests from every goroutine sharing the client at
+// most requestsPerSecond apart, and pauses them all when the store reports
+// its request quota used up, until the quota window resets. Zero keeps the
+// pauses without fixed pacing, which lets concurrent callers run at the
+// store's own limit without a burst of 429s when it is reached.
+func WithRateLimit(requestsPerSecond float64) ClientOption {
+	return func(c *Client) {
+		c.limiter = &rateLimiter{}
+		if requestsPerSecond > 0 {
+			c.limiter.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+		}
+	}
+}
+
+// defaultRequestTimeout bounds requests whose context has no deadline.
+const defaultRequestTimeout = 30 * time.Second
+
+// WithRequestTimeout sets how long a request may take when its context has
+// no deadline of its own; zero leaves such requests unbounded. A deadline on
+// the context always wins, longer or shorter, so slow batch or pricing calls
+// can be given more time with context.WithTimeout.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// NewClient returns a client for the store. Requests are bounded by their
+// context's deadline, or by defaultRequestTimeout when it has none; see
+// WithRequestTimeout.
+func NewClient(storeHash, authToken string, opts ...ClientOption) *Client {
+	// No http.Client.Timeout: it would cut off calls whose context allows
+	// longer
+	httpClient := &http.Client{}
 
 	baseURL, _ := url.Parse(defaultBaseURL + storeHash + "/" + apiVersion + "/")
 
@@ -64,11 +211,14 @@ func NewClient(storeHash, authToken string) *Client {
 		storeHash: storeHash,
 		authToken: authToken,
 		userAgent: userAgent,
+		timeout:   defaultRequestTimeout,
+		backoff:   &backoff{Base: retryBaseDelay, Max: retryMaxDelay, Multiplier: 2},
+		metrics:   noopMetricsObserver{},
 	}
 
-	c.Products = &ProductsService{client: c}
-	c.Categories = &CategoriesService{client: c}
-	c.Brands = &BrandsService{client: c}
+	c.Products = &ProductsService{client: c, resource: newCRUD[Product, ProductResponse, ProductsResponse](c, "catalog/products")}
+	c.Categories = &CategoriesService{client: c, resource: newCRUD[Category, CategoryResponse, CategoriesResponse](c, "catalog/categories")}
+	c.Brands = &BrandsService{client: c, resource: newCRUD[Brand, BrandResponse, BrandsResponse](c, "catalog/brands")}
 	c.Variants = &VariantsService{client: c}
 	c.ProductImages = &ProductImagesService{client: c}
 	c.ProductVideos = &VideosService{client: c}
@@ -87,10 +237,105 @@ func NewClient(storeHash, authToken string) *Client {
 	c.Pricing = &PricingService{client: c}
 	c.Inventory = &InventoryService{client: c}
 	c.BulkPricingRules = &BulkPricingRulesService{client: c}
+	c.Webhooks = &WebhooksService{client: c}
+	c.TaxClasses = &TaxClassesService{client: c}
+	c.GiftWrapping = &GiftWrappingService{client: c}
+	c.Carts = &CartsService{client: c}
+	c.BlogPosts = &BlogPostsService{client: c}
+	c.PriceListRecords = &PriceListRecordsService{client: c}
+	c.CustomerAttributes = &CustomerAttributesService{client: c}
+
+	for _, opt := range opts {
+		opt(c)
+	}
 
 	return c
 }
 
+const requestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// idempotencyKeyHeader carries a key identifying one logical create so a
+// retried POST can be recognised as a repeat rather than a new write, by the
+// API or by any proxy in front of it that honours the header.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyKey struct{}
+
+// WithIdempotencyKey sets the key sent with POST requests made with ctx.
+// Every POST made with the returned context shares the key, so scope it to a
+// single logical operation. Without one, NewRequest generates a key per
+// request.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey{}, key)
+}
+
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyKey{}).(string)
+	return key
+}
+
+type headersKey struct{}
+
+// WithHeaders adds header to every request made with ctx, replacing the
+// client's defaults for any key it names, e.g. Accept for a non-JSON
+// endpoint or a beta feature flag. Headers already set on ctx are kept
+// unless header names them too.
+func WithHeaders(ctx context.Context, header http.Header) context.Context {
+	merged := HeadersFromContext(ctx).Clone()
+	if merged == nil {
+		merged = make(http.Header, len(header))
+	}
+	for key, values := range header {
+		merged[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+	return context.WithValue(ctx, headersKey{}, merged)
+}
+
+func HeadersFromContext(ctx context.Context) http.Header {
+	header, _ := ctx.Value(headersKey{}).(http.Header)
+	return header
+}
+
+// ifMatchHeader makes a write conditional on the resource still carrying
+// the ETag it was read with, so concurrent editors cannot overwrite each
+// other's changes unnoticed.
+const ifMatchHeader = "If-Match"
+
+type ifMatchKey struct{}
+
+// WithIfMatch makes PUT, PATCH and DELETE requests made with ctx
+// conditional on etag, as returned in ProductResponse.ETag or
+// Response.ETag. A write to a resource changed since then fails with an
+// error wrapping ErrPreconditionFailed. Without one, writes are
+// unconditional.
+func WithIfMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, etag)
+}
+
+func IfMatchFromContext(ctx context.Context) string {
+	etag, _ := ctx.Value(ifMatchKey{}).(string)
+	return etag
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
 func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
 	rel, err := url.Parse(urlStr)
 	if err != nil {
@@ -118,454 +363,1721 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	req.Header.Set(requestIDHeader, requestID)
+
+	if method == http.MethodPost {
+		key := IdempotencyKeyFromContext(ctx)
+		if key == "" {
+			key = newRequestID()
+		}
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+
+	switch method {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		if etag := IfMatchFromContext(ctx); etag != "" {
+			req.Header.Set(ifMatchHeader, etag)
+		}
+	}
+
+	for key, values := range HeadersFromContext(ctx) {
+		req.Header[key] = append([]string(nil), values...)
+	}
+
 	return req, nil
 }
 
-func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := c.client.Do(req)
+// newUploadRequest builds a multipart POST carrying fields and the contents
+// of file as the image_file part, the shape every catalog image upload
+// endpoint expects.
+func (c *Client) newUploadRequest(ctx context.Context, path string, fields map[string]string, filename string, file io.Reader) (*http.Request, error) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	part, err := writer.CreateFormFile("image_file", filename)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
 
-	err = CheckResponse(resp)
+	req, err := c.NewRequest(ctx, "POST", path, nil)
 	if err != nil {
-		return resp, err
+		return nil, err
 	}
 
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, resp.Body)
-		} else {
-			err = json.NewDecoder(resp.Body).Decode(v)
-		}
+	data := body.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
 	}
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	return resp, err
+	return req, nil
 }
 
-type ErrorResponse struct {
-	Response *http.Response
-	Status   int      `json:"status"`
-	Title    string   `json:"title"`
-	Type     string   `json:"type"`
-	Errors   []string `json:"errors"`
+// idInChunkSize caps how many IDs one id:in filter on a listing names, so
+// the query string stays within the API's URL length limit.
+const idInChunkSize = 100
+
+// deleteIDChunkSize bounds how many IDs one filtered DELETE names, keeping
+// the query string well under URL length limits.
+const deleteIDChunkSize = 50
+
+// deleteMany deletes the resources at path matching ids with id:in filtered
+// DELETEs. IDs in a chunk that fails are all reported with its error, and
+// IDs not reached once ctx is cancelled with the context's error.
+func (c *Client) deleteMany(ctx context.Context, path string, ids []int) map[int]error {
+	errs := make(map[int]error)
+	for start := 0; start < len(ids); start += deleteIDChunkSize {
+		end := start + deleteIDChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunk := ids[start:end]
+
+		err := ctx.Err()
+		if err == nil {
+			var req *http.Request
+			req, err = c.NewRequest(ctx, "DELETE", path, nil)
+			if err == nil {
+				req.URL.RawQuery = (&QueryParams{IDIn: chunk}).ToValues().Encode()
+				_, err = c.Do(req, nil)
+			}
+		}
+		if err != nil {
+			for _, id := range chunk {
+				errs[id] = err
+			}
+		}
+	}
+	return errs
 }
 
-func (e *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %v - %v",
-		e.Response.Request.Method, e.Response.Request.URL,
-		e.Response.StatusCode, e.Title, e.Errors)
+type channelIDKey struct{}
+
+// WithChannelID scopes requests made with ctx to a channel. Pricing requests
+// and product listings use it when they do not name a channel themselves.
+func WithChannelID(ctx context.Context, channelID int) context.Context {
+	return context.WithValue(ctx, channelIDKey{}, channelID)
 }
 
-func CheckResponse(r *http.Response) error {
-	if c := r.StatusCode; c >= 200 && c <= 299 {
-		return nil
+func ChannelIDFromContext(ctx context.Context) (int, bool) {
+	channelID, ok := ctx.Value(channelIDKey{}).(int)
+	return channelID, ok
+}
+
+// withContextChannel returns params filtered to the context's channel unless
+// params already filters by channel.
+func withContextChannel(ctx context.Context, params *QueryParams) *QueryParams {
+	channelID, ok := ChannelIDFromContext(ctx)
+	if !ok || (params != nil && len(params.ChannelID) > 0) {
+		return params
 	}
 
-	errorResponse := &ErrorResponse{Response: r}
-	data, err := io.ReadAll(r.Body)
-	if err == nil && len(data) > 0 {
-		err := json.Unmarshal(data, errorResponse)
-		if err != nil {
-			return err
-		}
+	scoped := params.Clone()
+	if scoped == nil {
+		scoped = &QueryParams{}
 	}
+	scoped.ChannelID = []int{channelID}
+	return scoped
+}
 
-	return errorResponse
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
 }
 
-type QueryParams struct {
-	Page         int
-	Limit        int
-	Direction    string
-	Sort         string
-	Include      []string
-	ID           []int
-	IDIn         []int
-	IDNotIn      []int
-	IDMin        int
-	IDMax        int
-	IDGreater    int
-	IDLess       int
-	Name         string
-	SKU          string
-	Price        float64
-	PriceMin     float64
-	PriceMax     float64
-	Weight       float64
-	WeightMin    float64
-	WeightMax    float64
-	Condition    string
-	IsVisible    *bool
-	IsFeatured   *bool
-	CategoryID   []int
-	BrandID      []int
-	Keywords     string
-	IsActive     *bool
-	DateCreated  string
-	DateModified string
+type Span interface {
+	SetAttributes(attrs map[string]interface{})
+	RecordError(err error)
+	End()
 }
 
-func (q *QueryParams) ToValues() url.Values {
-	values := url.Values{}
+type noopSpan struct{}
 
-	if q.Page > 0 {
-		values.Add("page", strconv.Itoa(q.Page))
-	}
+func (noopSpan) SetAttributes(map[string]interface{}) {}
+func (noopSpan) RecordError(error)                    {}
+func (noopSpan) End()                                 {}
 
-	if q.Limit > 0 {
-		values.Add("limit", strconv.Itoa(q.Limit))
+type MetricsObserver interface {
+	ObserveRequest(resource, method string, status int, d time.Duration)
+}
+
+type noopMetricsObserver struct{}
+
+func (noopMetricsObserver) ObserveRequest(string, string, int, time.Duration) {}
+
+func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
+	if _, ok := req.Context().Deadline(); !ok && c.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
 	}
 
-	if q.Direction != "" {
-		values.Add("direction", q.Direction)
+	route := c.routeTemplate(req.URL.Path)
+
+	var span Span = noopSpan{}
+	if c.tracer != nil {
+		var ctx context.Context
+		ctx, span = c.tracer.Start(req.Context(), req.Method+" "+route)
+		req = req.WithContext(ctx)
 	}
+	defer span.End()
 
-	if q.Sort != "" {
-		values.Add("sort", q.Sort)
+	resp, retries, err := c.do(req, v)
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	span.SetAttributes(map[string]interface{}{
+		"http.request.method":       req.Method,
+		"http.route":                route,
+		"http.response.status_code": status,
+		"bigcommerce.retry_count":   retries,
+	})
+	if err != nil {
+		span.RecordError(err)
 	}
 
-	for _, include := range q.Include {
-		values.Add("include", include)
+	return resp, err
+}
+
+// do sends req, reporting how many retries it took. Retries resend req with
+// its headers intact so the idempotency key set by NewRequest is reused and
+// a repeated create is not taken as a new one.
+func (c *Client) do(req *http.Request, v interface{}) (*http.Response, int, error) {
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.wait(req.Context()); err != nil {
+				return nil, attempt, err
+			}
+		}
+
+		resp, err := c.send(req, v)
+		if c.limiter != nil {
+			c.limiter.observe(resp)
+		}
+
+		if attempt >= c.maxRetries || !retryable(req, resp, err) {
+			return resp, attempt, err
+		}
+
+		delay := c.retryDelay(resp, attempt)
+		c.logf("%s %s retrying in %s [request id: %s]: %v", req.Method, req.URL.Path, delay, req.Header.Get(requestIDHeader), err)
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return resp, attempt, err
+		}
+
+		req = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, attempt, err
+			}
+			req.Body = body
+		}
 	}
+}
 
-	for _, id := range q.ID {
-		values.Add("id", strconv.Itoa(id))
+// retryable reports whether a request may be sent again; see WithRetry.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	idempotent := req.Method != http.MethodPost && req.Method != http.MethodPatch
+
+	if resp == nil {
+		if err == nil || req.Context().Err() != nil {
+			return false
+		}
+		return idempotent || errors.Is(err, syscall.ECONNREFUSED)
 	}
 
-	for _, id := range q.IDIn {
-		values.Add("id:in", strconv.Itoa(id))
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
 	}
+	return false
+}
 
-	for _, id := range q.IDNotIn {
-		values.Add("id:not_in", strconv.Itoa(id))
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+func (c *Client) retryDelay(resp *http.Response, attempt int) time.Duration {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if reset := newResponse(resp, Meta{}).RateLimitReset(); reset > 0 {
+			return reset
+		}
 	}
 
-	if q.IDMin > 0 {
-		values.Add("id:min", strconv.Itoa(q.IDMin))
+	return c.backoff.Delay(attempt)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
 	}
 
-	if q.IDMax > 0 {
-		values.Add("id:max", strconv.Itoa(q.IDMax))
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
+}
 
-	if q.IDGreater > 0 {
-		values.Add("id:greater", strconv.Itoa(q.IDGreater))
+// rateLimiter hands out send times at least interval apart and pushes them
+// all back when a response says the quota is spent.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
 	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
 
-	if q.IDLess > 0 {
-		values.Add("id:less", strconv.Itoa(q.IDLess))
+	return sleepContext(ctx, time.Until(at))
+}
+
+func (l *rateLimiter) observe(resp *http.Response) {
+	if resp == nil {
+		return
 	}
 
-	if q.Name != "" {
-		values.Add("name", q.Name)
+	limits := newResponse(resp, Meta{})
+	spent := resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.Header.Get("X-Rate-Limit-Requests-Left") != "" && limits.RateLimitRemaining() <= 0)
+	reset := limits.RateLimitReset()
+	if !spent || reset <= 0 {
+		return
 	}
 
-	if q.SKU != "" {
-		values.Add("sku", q.SKU)
+	l.mu.Lock()
+	if until := time.Now().Add(reset); until.After(l.next) {
+		l.next = until
 	}
+	l.mu.Unlock()
+}
 
-	if q.Price > 0 {
-		values.Add("price", strconv.FormatFloat(q.Price, 'f', 2, 64))
+func (c *Client) send(req *http.Request, v interface{}) (*http.Response, error) {
+	// Streamed bodies are never buffered, so they bypass the cache
+	_, streamed := v.(streamDecoder)
+	cacheable := c.cache != nil && req.Method == http.MethodGet && !streamed
+
+	var cached CachedResponse
+	var hit bool
+	if cacheable {
+		cached, hit = c.cache.Get(req.URL.String())
+		if hit {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
 	}
 
-	if q.PriceMin > 0 {
-		values.Add("price:min", strconv.FormatFloat(q.PriceMin, 'f', 2, 64))
+	start := time.Now()
+	resp, err := c.client.Do(req)
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
 	}
+	c.metrics.ObserveRequest(c.routeTemplate(req.URL.Path), req.Method, status, time.Since(start))
 
-	if q.PriceMax > 0 {
-		values.Add("price:max", strconv.FormatFloat(q.PriceMax, 'f', 2, 64))
+	target := req.URL.Path
+	if c.logURLs {
+		target = req.URL.String()
 	}
 
-	if q.Weight > 0 {
-		values.Add("weight", strconv.FormatFloat(q.Weight, 'f', 2, 64))
+	if err != nil {
+		c.logf("%s %s failed [request id: %s]: %v", req.Method, target, req.Header.Get(requestIDHeader), err)
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	if q.WeightMin > 0 {
-		values.Add("weight:min", strconv.FormatFloat(q.WeightMin, 'f', 2, 64))
+	c.logf("%s %s %d [request id: %s]", req.Method, target, resp.StatusCode, req.Header.Get(requestIDHeader))
+
+	if hit && resp.StatusCode == http.StatusNotModified {
+		return resp, decodeBody(bytes.NewReader(cached.Body), v, c.strictDecoding)
 	}
 
-	if q.WeightMax > 0 {
-		values.Add("weight:max", strconv.FormatFloat(q.WeightMax, 'f', 2, 64))
+	err = CheckResponse(resp)
+	if err != nil {
+		return resp, err
 	}
 
-	if q.Condition != "" {
-		values.Add("condition", q.Condition)
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return resp, nil
 	}
 
-	if q.IsVisible != nil {
-		values.Add("is_visible", strconv.FormatBool(*q.IsVisible))
+	if etag := resp.Header.Get("ETag"); cacheable && etag != "" {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return resp, err
+		}
+		c.cache.Set(req.URL.String(), CachedResponse{ETag: etag, Body: body})
+		return resp, decodeBody(bytes.NewReader(body), v, c.strictDecoding)
 	}
 
-	if q.IsFeatured != nil {
-		values.Add("is_featured", strconv.FormatBool(*q.IsFeatured))
-	}
+	return resp, decodeBody(resp.Body, v, c.strictDecoding)
+}
 
-	for _, categoryID := range q.CategoryID {
-		values.Add("categories", strconv.Itoa(categoryID))
+func decodeBody(r io.Reader, v interface{}, strict bool) error {
+	if v == nil {
+		return nil
 	}
-
-	for _, brandID := range q.BrandID {
-		values.Add("brand_id", strconv.Itoa(brandID))
+	if d, ok := v.(streamDecoder); ok {
+		return d.decodeStream(r)
 	}
-
-	if q.Keywords != "" {
-		values.Add("keyword", q.Keywords)
+	if w, ok := v.(io.Writer); ok {
+		_, err := io.Copy(w, r)
+		return err
 	}
 
-	if q.IsActive != nil {
-		values.Add("is_active", strconv.FormatBool(*q.IsActive))
+	dec := json.NewDecoder(r)
+	if strict {
+		dec.DisallowUnknownFields()
 	}
+	return dec.Decode(v)
+}
 
-	if q.DateCreated != "" {
-		values.Add("date_created", q.DateCreated)
-	}
+// ResponseCache stores response bodies by request URL for conditional GETs.
+// Implementations must be safe for concurrent use.
+type ResponseCache interface {
+	Get(key string) (CachedResponse, bool)
+	Set(key string, response CachedResponse)
+}
 
-	if q.DateModified != "" {
-		values.Add("date_modified", q.DateModified)
+type CachedResponse struct {
+	ETag string
+	Body []byte
+}
+
+// MemoryResponseCache is an unbounded in-process ResponseCache.
+type MemoryResponseCache struct {
+	mu      sync.RWMutex
+	entries map[string]CachedResponse
+}
+
+func NewMemoryResponseCache() *MemoryResponseCache {
+	return &MemoryResponseCache{entries: make(map[string]CachedResponse)}
+}
+
+func (m *MemoryResponseCache) Get(key string) (CachedResponse, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	response, ok := m.entries[key]
+	return response, ok
+}
+
+func (m *MemoryResponseCache) Set(key string, response CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = response
+}
+
+// streamDecoder is implemented by response targets that consume the body
+// incrementally instead of decoding it in one go.
+type streamDecoder interface {
+	decodeStream(r io.Reader) error
+}
+
+// opaqueIDCollections are the collections whose members are addressed by
+// a non-numeric ID: cart UUIDs and abandoned cart tokens.
+var opaqueIDCollections = map[string]bool{
+	"carts":           true,
+	"abandoned-carts": true,
+}
+
+// routeTemplate strips the store prefix and replaces numeric IDs, and the
+// opaque IDs of opaqueIDCollections, so the result is safe to use as a
+// low-cardinality label.
+func (c *Client) routeTemplate(path string) string {
+	path = strings.TrimPrefix(path, "/stores/"+c.storeHash+"/")
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil || (i > 0 && opaqueIDCollections[segments[i-1]]) {
+			segments[i] = "{id}"
+		}
 	}
 
-	return values
+	return strings.Join(segments, "/")
 }
 
-type Meta struct {
-	Pagination struct {
-		Total       int `json:"total"`
-		Count       int `json:"count"`
-		PerPage     int `json:"per_page"`
-		CurrentPage int `json:"current_page"`
-		TotalPages  int `json:"total_pages"`
-		Links       struct {
-			Current  string `json:"current"`
-			Previous string `json:"previous"`
-			Next     string `json:"next"`
-		} `json:"links"`
-	} `json:"pagination"`
+// v2Path resolves a legacy v2 endpoint relative to the v3 base URL.
+func v2Path(path string) string {
+	return "../" + apiVersionV2 + "/" + path
 }
 
-type Product struct {
-	ID                  int             `json:"id,omitempty"`
-	Name                string          `json:"name"`
-	Type                string          `json:"type"`
-	SKU                 string          `json:"sku,omitempty"`
-	Description         string          `json:"description,omitempty"`
-	Weight              float64         `json:"weight,omitempty"`
-	Width               float64         `json:"width,omitempty"`
-	Depth               float64         `json:"depth,omitempty"`
-	Height              float64         `json:"height,omitempty"`
-	Price               float64         `json:"price"`
-	CostPrice           float64         `json:"cost_price,omitempty"`
-	RetailPrice         float64         `json:"retail_price,omitempty"`
-	SalePrice           float64         `json:"sale_price,omitempty"`
-	MapPrice            float64         `json:"map_price,omitempty"`
-	TaxClassID          int             `json:"tax_class_id,omitempty"`
-	ProductTaxCode      string          `json:"product_tax_code,omitempty"`
-	Categories          []int           `json:"categories,omitempty"`
-	BrandID             int             `json:"brand_id,omitempty"`
-	InventoryLevel      int             `json:"inventory_level,omitempty"`
-	InventoryWarning    int             `json:"inventory_warning_level,omitempty"`
-	InventoryTracking   string          `json:"inventory_tracking,omitempty"`
-	FixedCostShipping   float64         `json:"fixed_cost_shipping_price,omitempty"`
-	IsFreeShipping      bool            `json:"is_free_shipping,omitempty"`
-	IsVisible           bool            `json:"is_visible,omitempty"`
-	IsFeatured          bool            `json:"is_featured,omitempty"`
-	RelatedProducts     []int           `json:"related_products,omitempty"`
-	Warranty            string          `json:"warranty,omitempty"`
-	BinPickingNumber    string          `json:"bin_picking_number,omitempty"`
-	Layout              string          `json:"layout_file,omitempty"`
-	UPC                 string          `json:"upc,omitempty"`
-	MPN                 string          `json:"mpn,omitempty"`
-	GTIN                string          `json:"gtin,omitempty"`
-	SearchKeywords      string          `json:"search_keywords,omitempty"`
-	Availability        string          `json:"availability,omitempty"`
-	AvailabilityDesc    string          `json:"availability_description,omitempty"`
-	GiftWrappingOpts    string          `json:"gift_wrapping_options_type,omitempty"`
-	SortOrder           int             `json:"sort_order,omitempty"`
-	Condition           string          `json:"condition,omitempty"`
-	IsConditionShown    bool            `json:"is_condition_shown,omitempty"`
-	OrderQuantityMin    int             `json:"order_quantity_minimum,omitempty"`
-	OrderQuantityMax    int             `json:"order_quantity_maximum,omitempty"`
-	PageTitle           string          `json:"page_title,omitempty"`
-	MetaKeywords        []string        `json:"meta_keywords,omitempty"`
-	MetaDescription     string          `json:"meta_description,omitempty"`
-	DateCreated         string          `json:"date_created,omitempty"`
-	DateModified        string          `json:"date_modified,omitempty"`
-	ViewCount           int             `json:"view_count,omitempty"`
-	PreorderReleaseDate string          `json:"preorder_release_date,omitempty"`
-	PreorderMessage     string          `json:"preorder_message,omitempty"`
-	IsPreorderOnly      bool            `json:"is_preorder_only,omitempty"`
-	IsPriceHidden       bool            `json:"is_price_hidden,omitempty"`
-	PriceHiddenLabel    string          `json:"price_hidden_label,omitempty"`
-	CustomURL           *CustomURL      `json:"custom_url,omitempty"`
-	BaseVariantID       int             `json:"base_variant_id,omitempty"`
-	OpenGraphType       string          `json:"open_graph_type,omitempty"`
-	OpenGraphTitle      string          `json:"open_graph_title,omitempty"`
-	OpenGraphDesc       string          `json:"open_graph_description,omitempty"`
-	Images              []ProductImage  `json:"images,omitempty"`
-	Videos              []ProductVideo  `json:"videos,omitempty"`
-	CustomFields        []CustomField   `json:"custom_fields,omitempty"`
-	BulkPricingRules    []PricingRule   `json:"bulk_pricing_rules,omitempty"`
-	Variants            []Variant       `json:"variants,omitempty"`
-	Options             []ProductOption `json:"options,omitempty"`
-	Modifiers           []Modifier      `json:"modifiers,omitempty"`
-	Reviews             []Review        `json:"reviews,omitempty"`
-	ComplexRules        []ComplexRule   `json:"complex_rules,omitempty"`
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, args...)
+	}
 }
 
-type ProductResponse struct {
-	Data Product `json:"data"`
-	Meta Meta    `json:"meta"`
+type Response struct {
+	*http.Response
+
+	Meta Meta
 }
 
-type ProductsResponse struct {
-	Data []Product `json:"data"`
-	Meta Meta      `json:"meta"`
+func newResponse(r *http.Response, meta Meta) *Response {
+	if r == nil {
+		return nil
+	}
+	return &Response{Response: r, Meta: meta}
 }
 
-type ProductImage struct {
-	ID           int    `json:"id,omitempty"`
-	ProductID    int    `json:"product_id,omitempty"`
-	IsThumbnail  bool   `json:"is_thumbnail,omitempty"`
-	SortOrder    int    `json:"sort_order,omitempty"`
-	Description  string `json:"description,omitempty"`
-	ImageFile    string `json:"image_file,omitempty"`
-	URLZoom      string `json:"url_zoom,omitempty"`
-	URLStandard  string `json:"url_standard,omitempty"`
-	URLThumbnail string `json:"url_thumbnail,omitempty"`
-	URLTiny      string `json:"url_tiny,omitempty"`
-	DateModified string `json:"date_modified,omitempty"`
+func (r *Response) RequestID() string {
+	return r.Request.Header.Get(requestIDHeader)
 }
 
-type ProductVideo struct {
-	ID          int    `json:"id,omitempty"`
-	ProductID   int    `json:"product_id,omitempty"`
-	Title       string `json:"title,omitempty"`
-	Description string `json:"description,omitempty"`
-	SortOrder   int    `json:"sort_order,omitempty"`
-	Type        string `json:"type,omitempty"`
-	VideoID     string `json:"video_id,omitempty"`
-	URL         string `json:"url,omitempty"`
+func (r *Response) RateLimitRemaining() int {
+	remaining, _ := strconv.Atoi(r.Header.Get("X-Rate-Limit-Requests-Left"))
+	return remaining
 }
 
-type ProductImageResponse struct {
-	Data ProductImage `json:"data"`
-	Meta Meta         `json:"meta"`
+func (r *Response) RateLimitQuota() int {
+	quota, _ := strconv.Atoi(r.Header.Get("X-Rate-Limit-Requests-Quota"))
+	return quota
 }
 
-type ProductImagesResponse struct {
-	Data []ProductImage `json:"data"`
-	Meta Meta           `json:"meta"`
+func (r *Response) RateLimitReset() time.Duration {
+	ms, _ := strconv.Atoi(r.Header.Get("X-Rate-Limit-Time-Reset-Ms"))
+	return time.Duration(ms) * time.Millisecond
 }
 
-type ProductVideoResponse struct {
-	Data ProductVideo `json:"data"`
-	Meta Meta         `json:"meta"`
+// ETag returns the version of the resource the response describes, for use
+// with WithIfMatch.
+func (r *Response) ETag() string {
+	return r.Header.Get("ETag")
 }
 
-type ProductVideosResponse struct {
-	Data []ProductVideo `json:"data"`
-	Meta Meta           `json:"meta"`
+// Sentinel errors that an *ErrorResponse unwraps to based on its status
+// code, so callers can use errors.Is instead of inspecting the response.
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrRateLimited        = errors.New("rate limited")
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+type ErrorResponse struct {
+	Response *http.Response
+	Status   int      `json:"status"`
+	Title    string   `json:"title"`
+	Type     string   `json:"type"`
+	Errors   []string `json:"errors"`
+
+	// FieldErrors holds v3 validation errors, which are keyed by field, or
+	// by "<index>.<field>" for batch requests.
+	FieldErrors map[string]string `json:"-"`
 }
 
-type CustomURL struct {
-	URL          string `json:"url,omitempty"`
-	IsCustomized bool   `json:"is_customized,omitempty"`
+// UnmarshalJSON accepts errors as either a list of messages or an object
+// keyed by field.
+func (e *ErrorResponse) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Status int             `json:"status"`
+		Title  string          `json:"title"`
+		Type   string          `json:"type"`
+		Errors json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	e.Status, e.Title, e.Type = raw.Status, raw.Title, raw.Type
+
+	if len(raw.Errors) == 0 {
+		return nil
+	}
+	if raw.Errors[0] == '{' {
+		return json.Unmarshal(raw.Errors, &e.FieldErrors)
+	}
+	return json.Unmarshal(raw.Errors, &e.Errors)
 }
 
-type CustomField struct {
-	ID        int    `json:"id,omitempty"`
-	Name      string `json:"name"`
-	Value     string `json:"value"`
-	ProductID int    `json:"product_id,omitempty"`
+func (e *ErrorResponse) Error() string {
+	details := interface{}(e.Errors)
+	if len(e.FieldErrors) > 0 {
+		details = e.FieldErrors
+	}
+	msg := fmt.Sprintf("%v %v: %d %v - %v",
+		e.Response.Request.Method, e.Response.Request.URL,
+		e.Response.StatusCode, e.Title, details)
+	if requestID := e.Response.Request.Header.Get(requestIDHeader); requestID != "" {
+		msg += fmt.Sprintf(" [request id: %s]", requestID)
+	}
+	return msg
 }
 
-type PricingRule struct {
-	ID          int     `json:"id,omitempty"`
-	QuantityMin int     `json:"quantity_min"`
-	QuantityMax int     `json:"quantity_max,omitempty"`
-	Type        string  `json:"type"`
-	Amount      float64 `json:"amount"`
-	ProductID   int     `json:"product_id,omitempty"`
+// Unwrap maps 404 to ErrNotFound, 429 to ErrRateLimited, 401 or 403 to
+// ErrUnauthorized and 412 to ErrPreconditionFailed.
+func (e *ErrorResponse) Unwrap() error {
+	switch e.Response.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	}
+	return nil
 }
 
-type Variant struct {
-	ID                     int           `json:"id,omitempty"`
-	ProductID              int           `json:"product_id,omitempty"`
-	SKU                    string        `json:"sku,omitempty"`
-	Price                  float64       `json:"price,omitempty"`
-	CostPrice              float64       `json:"cost_price,omitempty"`
-	SalePrice              float64       `json:"sale_price,omitempty"`
-	RetailPrice            float64       `json:"retail_price,omitempty"`
-	Weight                 float64       `json:"weight,omitempty"`
-	Width                  float64       `json:"width,omitempty"`
-	Height                 float64       `json:"height,omitempty"`
-	Depth                  float64       `json:"depth,omitempty"`
-	IsFree                 bool          `json:"is_free_shipping,omitempty"`
-	FixedCostShippingPrice float64       `json:"fixed_cost_shipping_price,omitempty"`
-	PurchasingDisabled     bool          `json:"purchasing_disabled,omitempty"`
-	PurchasingDisabledMsg  string        `json:"purchasing_disabled_message,omitempty"`
-	ImageURL               string        `json:"image_url,omitempty"`
-	UPC                    string        `json:"upc,omitempty"`
-	MPN                    string        `json:"mpn,omitempty"`
-	GTIN                   string        `json:"gtin,omitempty"`
-	InventoryLevel         int           `json:"inventory_level,omitempty"`
-	InventoryWarningLevel  int           `json:"inventory_warning_level,omitempty"`
-	BinPickingNumber       string        `json:"bin_picking_number,omitempty"`
-	OptionValues           []OptionValue `json:"option_values"`
+// IsNotFound reports whether err is or wraps a 404 response, or a GET
+// that succeeded without data.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
 }
 
-type OptionValue struct {
-	ID        int    `json:"id,omitempty"`
-	OptionID  int    `json:"option_id"`
-	Label     string `json:"label,omitempty"`
-	SortOrder int    `json:"sort_order,omitempty"`
-	Value     string `json:"value,omitempty"`
-	IsDefault bool   `json:"is_default,omitempty"`
+// emptyGet reports a successful GET of path that came back without data,
+// which the store can return for a resource deleted moments earlier. It
+// wraps ErrNotFound so callers handle it like a 404.
+func emptyGet(path string) error {
+	return fmt.Errorf("GET %s: empty data: %w", path, ErrNotFound)
 }
 
-type ProductOption struct {
-	ID           int           `json:"id,omitempty"`
-	ProductID    int           `json:"product_id,omitempty"`
-	DisplayName  string        `json:"display_name"`
-	Type         string        `json:"type"`
-	Config       OptionConfig  `json:"config,omitempty"`
-	OptionValues []OptionValue `json:"option_values,omitempty"`
+// IsRateLimited reports whether err is or wraps a 429 response.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
 }
 
-type OptionConfig struct {
-	DefaultValue      string   `json:"default_value,omitempty"`
-	CheckedByDefault  bool     `json:"checked_by_default,omitempty"`
-	CheckboxLabel     string   `json:"checkbox_label,omitempty"`
-	DateLimited       bool     `json:"date_limited,omitempty"`
-	DateLimitMode     string   `json:"date_limit_mode,omitempty"`
-	DateEarliestValue string   `json:"date_earliest_value,omitempty"`
-	DateLatestValue   string   `json:"date_latest_value,omitempty"`
-	FileTypes         []string `json:"file_types_supported,omitempty"`
-	FileMaxSize       int      `json:"file_max_size,omitempty"`
-	TextMinLength     int      `json:"text_min_length,omitempty"`
-	TextMaxLength     int      `json:"text_max_length,omitempty"`
-	TextCharsLimit    int      `json:"text_characters_limited,omitempty"`
-	NumberLimited     bool     `json:"number_limited,omitempty"`
-	NumberLimitMode   string   `json:"number_limit_mode,omitempty"`
-	NumberMin         float64  `json:"number_lowest_value,omitempty"`
-	NumberMax         float64  `json:"number_highest_value,omitempty"`
-	NumberIntOnly     bool     `json:"number_integers_only,omitempty"`
+// IsUnauthorized reports whether err is or wraps a 401 or 403 response.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
 }
 
-type Modifier struct {
-	ID           int            `json:"id,omitempty"`
-	ProductID    int            `json:"product_id,omitempty"`
-	Name         string         `json:"name"`
-	DisplayName  string         `json:"display_name"`
-	Type         string         `json:"type"`
-	Required     bool           `json:"required"`
-	Config       ModifierConfig `json:"config,omitempty"`
-	OptionValues []OptionValue  `json:"option_values,omitempty"`
+// IsPreconditionFailed reports whether err is or wraps a 412 response, as
+// returned when a write made WithIfMatch finds the resource has changed.
+func IsPreconditionFailed(err error) bool {
+	return errors.Is(err, ErrPreconditionFailed)
 }
 
-type ModifierConfig struct {
-	DefaultValue          string   `json:"default_value,omitempty"`
+// IsValidationError reports whether err is or wraps a response rejecting
+// the request body: a 400, 409 or 422.
+func IsValidationError(err error) bool {
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		return false
+	}
+	switch errorResponse.Response.StatusCode {
+	case http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity:
+		return true
+	}
+	return false
+}
+
+func CheckResponse(r *http.Response) error {
+	if c := r.StatusCode; c >= 200 && c <= 299 {
+		return nil
+	}
+
+	errorResponse := &ErrorResponse{Response: r}
+	data, err := io.ReadAll(r.Body)
+	if err == nil && len(data) > 0 {
+		// A body that is not the documented error shape still leaves the
+		// status code to report and unwrap
+		_ = json.Unmarshal(data, errorResponse)
+	}
+
+	return errorResponse
+}
+
+type QueryParams struct {
+	Page         int
+	Limit        int
+	Direction    string
+	Sort         string
+	Include      []string
+	ID           []int
+	IDIn         []int
+	IDNotIn      []int
+	IDMin        int
+	IDMax        int
+	IDGreater    int
+	IDLess       int
+	Name         string
+	SKU          string
+	Price        float64
+	PriceMin     float64
+	PriceMax     float64
+	Weight       float64
+	WeightMin    float64
+	WeightMax    float64
+	Condition    string
+	IsVisible    *bool
+	IsFeatured   *bool
+	CategoryID   []int
+	BrandID      []int
+	Keywords     string
+	IsActive     *bool
+	DateCreated  string
+	DateModified string
+
+	DateCreatedMin  string
+	DateCreatedMax  string
+	DateModifiedMin string
+	DateModifiedMax string
+
+	ChannelID []int
+}
+
+// String returns the encoded query string the params produce, as sent on
+// the request URL.
+func (q *QueryParams) String() string {
+	if q == nil {
+		return ""
+	}
+	return q.ToValues().Encode()
+}
+
+// HasFilter reports whether the params narrow which resources match, as
+// opposed to only paging, sorting or including sub-resources.
+func (q *QueryParams) HasFilter() bool {
+	if q == nil {
+		return false
+	}
+	for key := range q.ToValues() {
+		switch key {
+		case "page", "limit", "direction", "sort", "include":
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+func (q *QueryParams) ToValues() url.Values {
+	values := url.Values{}
+
+	if q.Page > 0 {
+		values.Add("page", strconv.Itoa(q.Page))
+	}
+
+	if q.Limit > 0 {
+		values.Add("limit", strconv.Itoa(q.Limit))
+	}
+
+	if q.Direction != "" {
+		values.Add("direction", q.Direction)
+	}
+
+	if q.Sort != "" {
+		values.Add("sort", q.Sort)
+	}
+
+	for _, include := range q.Include {
+		values.Add("include", include)
+	}
+
+	for _, id := range q.ID {
+		values.Add("id", strconv.Itoa(id))
+	}
+
+	for _, id := range q.IDIn {
+		values.Add("id:in", strconv.Itoa(id))
+	}
+
+	for _, id := range q.IDNotIn {
+		values.Add("id:not_in", strconv.Itoa(id))
+	}
+
+	if q.IDMin > 0 {
+		values.Add("id:min", strconv.Itoa(q.IDMin))
+	}
+
+	if q.IDMax > 0 {
+		values.Add("id:max", strconv.Itoa(q.IDMax))
+	}
+
+	if q.IDGreater > 0 {
+		values.Add("id:greater", strconv.Itoa(q.IDGreater))
+	}
+
+	if q.IDLess > 0 {
+		values.Add("id:less", strconv.Itoa(q.IDLess))
+	}
+
+	if q.Name != "" {
+		values.Add("name", q.Name)
+	}
+
+	if q.SKU != "" {
+		values.Add("sku", q.SKU)
+	}
+
+	if q.Price > 0 {
+		values.Add("price", strconv.FormatFloat(q.Price, 'f', 2, 64))
+	}
+
+	if q.PriceMin > 0 {
+		values.Add("price:min", strconv.FormatFloat(q.PriceMin, 'f', 2, 64))
+	}
+
+	if q.PriceMax > 0 {
+		values.Add("price:max", strconv.FormatFloat(q.PriceMax, 'f', 2, 64))
+	}
+
+	if q.Weight > 0 {
+		values.Add("weight", strconv.FormatFloat(q.Weight, 'f', 2, 64))
+	}
+
+	if q.WeightMin > 0 {
+		values.Add("weight:min", strconv.FormatFloat(q.WeightMin, 'f', 2, 64))
+	}
+
+	if q.WeightMax > 0 {
+		values.Add("weight:max", strconv.FormatFloat(q.WeightMax, 'f', 2, 64))
+	}
+
+	if q.Condition != "" {
+		values.Add("condition", q.Condition)
+	}
+
+	if q.IsVisible != nil {
+		values.Add("is_visible", strconv.FormatBool(*q.IsVisible))
+	}
+
+	if q.IsFeatured != nil {
+		values.Add("is_featured", strconv.FormatBool(*q.IsFeatured))
+	}
+
+	for _, categoryID := range q.CategoryID {
+		values.Add("categories", strconv.Itoa(categoryID))
+	}
+
+	for _, brandID := range q.BrandID {
+		values.Add("brand_id", strconv.Itoa(brandID))
+	}
+
+	if q.Keywords != "" {
+		values.Add("keyword", q.Keywords)
+	}
+
+	if q.IsActive != nil {
+		values.Add("is_active", strconv.FormatBool(*q.IsActive))
+	}
+
+	if q.DateCreated != "" {
+		values.Add("date_created", q.DateCreated)
+	}
+
+	if q.DateModified != "" {
+		values.Add("date_modified", q.DateModified)
+	}
+
+	if q.DateCreatedMin != "" {
+		values.Add("date_created:min", q.DateCreatedMin)
+	}
+
+	if q.DateCreatedMax != "" {
+		values.Add("date_created:max", q.DateCreatedMax)
+	}
+
+	if q.DateModifiedMin != "" {
+		values.Add("date_modified:min", q.DateModifiedMin)
+	}
+
+	if q.DateModifiedMax != "" {
+		values.Add("date_modified:max", q.DateModifiedMax)
+	}
+
+	for _, channelID := range q.ChannelID {
+		values.Add("channel_id:in", strconv.Itoa(channelID))
+	}
+
+	return values
+}
+
+func (q *QueryParams) Clone() *QueryParams {
+	if q == nil {
+		return nil
+	}
+
+	clone := *q
+
+	clone.Include = cloneStrings(q.Include)
+	clone.ID = cloneInts(q.ID)
+	clone.IDIn = cloneInts(q.IDIn)
+	clone.IDNotIn = cloneInts(q.IDNotIn)
+	clone.CategoryID = cloneInts(q.CategoryID)
+	clone.BrandID = cloneInts(q.BrandID)
+	clone.ChannelID = cloneInts(q.ChannelID)
+
+	clone.IsVisible = cloneBool(q.IsVisible)
+	clone.IsFeatured = cloneBool(q.IsFeatured)
+	clone.IsActive = cloneBool(q.IsActive)
+
+	return &clone
+}
+
+// Merge returns a copy of q with every field set in override applied on
+// top. Either side may be nil.
+func (q *QueryParams) Merge(override *QueryParams) *QueryParams {
+	if q == nil {
+		return override.Clone()
+	}
+
+	merged := q.Clone()
+	if override == nil {
+		return merged
+	}
+
+	mergeInt(&merged.Page, override.Page)
+	mergeInt(&merged.Limit, override.Limit)
+	mergeString(&merged.Direction, override.Direction)
+	mergeString(&merged.Sort, override.Sort)
+	mergeStrings(&merged.Include, override.Include)
+	mergeInts(&merged.ID, override.ID)
+	mergeInts(&merged.IDIn, override.IDIn)
+	mergeInts(&merged.IDNotIn, override.IDNotIn)
+	mergeInt(&merged.IDMin, override.IDMin)
+	mergeInt(&merged.IDMax, override.IDMax)
+	mergeInt(&merged.IDGreater, override.IDGreater)
+	mergeInt(&merged.IDLess, override.IDLess)
+	mergeString(&merged.Name, override.Name)
+	mergeString(&merged.SKU, override.SKU)
+	mergeFloat(&merged.Price, override.Price)
+	mergeFloat(&merged.PriceMin, override.PriceMin)
+	mergeFloat(&merged.PriceMax, override.PriceMax)
+	mergeFloat(&merged.Weight, override.Weight)
+	mergeFloat(&merged.WeightMin, override.WeightMin)
+	mergeFloat(&merged.WeightMax, override.WeightMax)
+	mergeString(&merged.Condition, override.Condition)
+	mergeBool(&merged.IsVisible, override.IsVisible)
+	mergeBool(&merged.IsFeatured, override.IsFeatured)
+	mergeInts(&merged.CategoryID, override.CategoryID)
+	mergeInts(&merged.BrandID, override.BrandID)
+	mergeString(&merged.Keywords, override.Keywords)
+	mergeBool(&merged.IsActive, override.IsActive)
+	mergeString(&merged.DateCreated, override.DateCreated)
+	mergeString(&merged.DateModified, override.DateModified)
+	mergeString(&merged.DateCreatedMin, override.DateCreatedMin)
+	mergeString(&merged.DateCreatedMax, override.DateCreatedMax)
+	mergeString(&merged.DateModifiedMin, override.DateModifiedMin)
+	mergeString(&merged.DateModifiedMax, override.DateModifiedMax)
+	mergeInts(&merged.ChannelID, override.ChannelID)
+
+	return merged
+}
+
+func mergeInt(dst *int, v int) {
+	if v != 0 {
+		*dst = v
+	}
+}
+
+func mergeFloat(dst *float64, v float64) {
+	if v != 0 {
+		*dst = v
+	}
+}
+
+func mergeString(dst *string, v string) {
+	if v != "" {
+		*dst = v
+	}
+}
+
+func mergeStrings(dst *[]string, v []string) {
+	if v != nil {
+		*dst = cloneStrings(v)
+	}
+}
+
+func mergeInts(dst *[]int, v []int) {
+	if v != nil {
+		*dst = cloneInts(v)
+	}
+}
+
+func mergeBool(dst **bool, v *bool) {
+	if v != nil {
+		*dst = cloneBool(v)
+	}
+}
+
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(s)), s...)
+}
+
+func cloneInts(s []int) []int {
+	if s == nil {
+		return nil
+	}
+	return append(make([]int, 0, len(s)), s...)
+}
+
+func cloneBool(b *bool) *bool {
+	if b == nil {
+		return nil
+	}
+	v := *b
+	return &v
+}
+
+type Meta struct {
+	Pagination struct {
+		Total       int `json:"total"`
+		Count       int `json:"count"`
+		PerPage     int `json:"per_page"`
+		CurrentPage int `json:"current_page"`
+		TotalPages  int `json:"total_pages"`
+		Links       struct {
+			Current  string `json:"current"`
+			Previous string `json:"previous"`
+			Next     string `json:"next"`
+		} `json:"links"`
+	} `json:"pagination"`
+}
+
+// Paginated is implemented by every response carrying Meta, so paging code
+// can read Meta.Pagination without knowing the resource. Single-resource
+// and batch responses report an empty Pagination. The v2 endpoints return
+// bare arrays with no Meta and are paged by page and limit alone.
+type Paginated interface {
+	GetPagination() Meta
+}
+
+func (r *Response) GetPagination() Meta                          { return r.Meta }
+func (r *ProductResponse) GetPagination() Meta                   { return r.Meta }
+func (r *ProductsResponse) GetPagination() Meta                  { return r.Meta }
+func (r *ProductImageResponse) GetPagination() Meta              { return r.Meta }
+func (r *ProductImagesResponse) GetPagination() Meta             { return r.Meta }
+func (r *ProductVideoResponse) GetPagination() Meta              { return r.Meta }
+func (r *ProductVideosResponse) GetPagination() Meta             { return r.Meta }
+func (r *CategoryResponse) GetPagination() Meta                  { return r.Meta }
+func (r *CategoriesResponse) GetPagination() Meta                { return r.Meta }
+func (r *BrandResponse) GetPagination() Meta                     { return r.Meta }
+func (r *BrandsResponse) GetPagination() Meta                    { return r.Meta }
+func (r *VariantResponse) GetPagination() Meta                   { return r.Meta }
+func (r *VariantsResponse) GetPagination() Meta                  { return r.Meta }
+func (r *OptionValueResponse) GetPagination() Meta               { return r.Meta }
+func (r *OptionValuesResponse) GetPagination() Meta              { return r.Meta }
+func (r *ProductOptionResponse) GetPagination() Meta             { return r.Meta }
+func (r *ProductOptionsResponse) GetPagination() Meta            { return r.Meta }
+func (r *ModifierResponse) GetPagination() Meta                  { return r.Meta }
+func (r *ModifiersResponse) GetPagination() Meta                 { return r.Meta }
+func (r *ReviewResponse) GetPagination() Meta                    { return r.Meta }
+func (r *ReviewsResponse) GetPagination() Meta                   { return r.Meta }
+func (r *ComplexRuleResponse) GetPagination() Meta               { return r.Meta }
+func (r *ComplexRulesResponse) GetPagination() Meta              { return r.Meta }
+func (r *CustomFieldResponse) GetPagination() Meta               { return r.Meta }
+func (r *CustomFieldsResponse) GetPagination() Meta              { return r.Meta }
+func (r *PricingRuleResponse) GetPagination() Meta               { return r.Meta }
+func (r *PricingRulesResponse) GetPagination() Meta              { return r.Meta }
+func (r *ChannelResponse) GetPagination() Meta                   { return r.Meta }
+func (r *ChannelsResponse) GetPagination() Meta                  { return r.Meta }
+func (r *MetafieldResponse) GetPagination() Meta                 { return r.Meta }
+func (r *MetafieldsResponse) GetPagination() Meta                { return r.Meta }
+func (r *SummaryResponse) GetPagination() Meta                   { return r.Meta }
+func (r *ProductSortOrdersResponse) GetPagination() Meta         { return r.Meta }
+func (r *ProductChannelAssignmentsResponse) GetPagination() Meta { return r.Meta }
+func (r *CategoryAssignmentsResponse) GetPagination() Meta       { return r.Meta }
+func (r *BatchErrorResponse) GetPagination() Meta                { return r.Meta }
+func (r *BatchProductsResponse) GetPagination() Meta             { return r.Meta }
+func (r *PricingResponse) GetPagination() Meta                   { return r.Meta }
+func (r *ProductInventoryResponse) GetPagination() Meta          { return r.Meta }
+func (r *ProductInventoriesResponse) GetPagination() Meta        { return r.Meta }
+func (r *BulkPricingRuleResponse) GetPagination() Meta           { return r.Meta }
+func (r *WebhookResponse) GetPagination() Meta                   { return r.Meta }
+func (r *WebhooksResponse) GetPagination() Meta                  { return r.Meta }
+func (r *CartResponse) GetPagination() Meta                      { return r.Meta }
+func (r *AbandonedCartResponse) GetPagination() Meta             { return r.Meta }
+func (r *PriceListRecordsResponse) GetPagination() Meta          { return r.Meta }
+func (r *CustomerAttributesResponse) GetPagination() Meta        { return r.Meta }
+func (r *CustomerAttributeValuesResponse) GetPagination() Meta   { return r.Meta }
+
+// listDefaults holds QueryParams merged under every list call a service
+// makes.
+type listDefaults struct {
+	defaults *QueryParams
+}
+
+// SetDefaults sets params applied to every list call on the service. Params
+// passed to an individual call override them field by field. It must not be
+// called concurrently with requests on the same service.
+func (d *listDefaults) SetDefaults(params *QueryParams) {
+	d.defaults = params.Clone()
+}
+
+func (d *listDefaults) withDefaults(params *QueryParams) *QueryParams {
+	return d.defaults.Merge(params)
+}
+
+const maxPageLimit = 250
+
+// ErrBudgetExhausted is wrapped by the error of a multi-request operation
+// cut short by a WithBudget context.
+var ErrBudgetExhausted = errors.New("time budget exhausted")
+
+// WithBudget bounds everything done with the returned context to d, like
+// context.WithTimeout. Operations that page or chunk, the ListAll methods,
+// StreamContext, GetManyContext, PatchProductsContext and
+// UpsertBatchContext, treat running out as a partial result: they return
+// what was gathered or written so far together with an error wrapping
+// ErrBudgetExhausted, so callers can keep the results and check
+// errors.Is(err, ErrBudgetExhausted). Single requests just fail with the
+// context's error. Call cancel once the operation is done.
+func WithBudget(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeoutCause(ctx, d, ErrBudgetExhausted)
+}
+
+// budgetError marks err as the result of ctx's budget running out, when it
+// has.
+func budgetError(ctx context.Context, err error) error {
+	if err != nil && errors.Is(context.Cause(ctx), ErrBudgetExhausted) && !errors.Is(err, ErrBudgetExhausted) {
+		return fmt.Errorf("%w: %w", ErrBudgetExhausted, err)
+	}
+	return err
+}
+
+// listAll requests successive pages until Meta.Pagination reports the last
+// one. params is cloned so the caller's page and limit are left untouched.
+// On error the items from earlier pages are returned with it.
+func listAll[T any](ctx context.Context, params *QueryParams, fetch func(context.Context, *QueryParams) ([]T, Meta, error)) ([]T, error) {
+	pageParams := params.Clone()
+	if pageParams == nil {
+		pageParams = &QueryParams{}
+	}
+	if pageParams.Limit == 0 {
+		pageParams.Limit = maxPageLimit
+	}
+	if pageParams.Page == 0 {
+		pageParams.Page = 1
+	}
+
+	var all []T
+	for {
+		items, meta, err := fetch(ctx, pageParams)
+		if err != nil {
+			return all, budgetError(ctx, err)
+		}
+		all = append(all, items...)
+
+		if len(items) == 0 || meta.Pagination.CurrentPage >= meta.Pagination.TotalPages {
+			return all, nil
+		}
+		pageParams.Page = meta.Pagination.CurrentPage + 1
+	}
+}
+
+// deleteAll lists every item with fetch, then deletes each by ID, carrying
+// on past failures and returning them joined.
+func deleteAll[T any](ctx context.Context, fetch func(context.Context, *QueryParams) ([]T, Meta, error), id func(T) int, del func(context.Context, int) error) error {
+	items, err := listAll(ctx, nil, fetch)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, item := range items {
+		if err := del(ctx, id(item)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %d: %w", id(item), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// crud issues the list, get, create, update and delete requests shared by
+// the top-level catalog resources under path. T is the resource, R its
+// single-item response and L its list response. Services keep their typed
+// methods and delegate here, adding their own defaults to list params.
+type crud[T, R, L any] struct {
+	client *Client
+	path   string
+}
+
+func newCRUD[T, R, L any](client *Client, path string) crud[T, R, L] {
+	return crud[T, R, L]{client: client, path: path}
+}
+
+func (r crud[T, R, L]) list(ctx context.Context, params *QueryParams) (*L, *Response, error) {
+	return doResource[L](ctx, r.client, "GET", r.path, params, nil)
+}
+
+func (r crud[T, R, L]) get(ctx context.Context, id int, params *QueryParams) (*R, *Response, error) {
+	return doResource[R](ctx, r.client, "GET", fmt.Sprintf("%s/%d", r.path, id), params, nil)
+}
+
+func (r crud[T, R, L]) create(ctx context.Context, item *T) (*R, *Response, error) {
+	return doResource[R](ctx, r.client, "POST", r.path, nil, item)
+}
+
+func (r crud[T, R, L]) update(ctx context.Context, id int, item *T) (*R, *Response, error) {
+	return doResource[R](ctx, r.client, "PUT", fmt.Sprintf("%s/%d", r.path, id), nil, item)
+}
+
+func (r crud[T, R, L]) delete(ctx context.Context, id int) error {
+	req, err := r.client.NewRequest(ctx, "DELETE", fmt.Sprintf("%s/%d", r.path, id), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.Do(req, nil)
+	return err
+}
+
+// doResource sends one request and decodes the body into a new V, reading
+// Meta from it when V is Paginated.
+func doResource[V any](ctx context.Context, c *Client, method, path string, params *QueryParams, body interface{}) (*V, *Response, error) {
+	req, err := c.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
+
+	v := new(V)
+	resp, err := c.Do(req, v)
+
+	var meta Meta
+	if p, ok := interface{}(v).(Paginated); ok {
+		meta = p.GetPagination()
+	}
+	return v, newResponse(resp, meta), err
+}
+
+// nextPage follows the pagination next link in meta, decoding the page into
+// v. It reports false without a request when meta describes the last page.
+// The link carries the original query, so filters and limit are preserved.
+func (c *Client) nextPage(ctx context.Context, path string, meta Meta, v interface{}) (bool, error) {
+	if meta.Pagination.CurrentPage >= meta.Pagination.TotalPages {
+		return false, nil
+	}
+
+	next, err := c.NextPageURL(path, meta)
+	if errors.Is(err, ErrNoNextPage) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = c.GetURL(ctx, next, v)
+	return err == nil, err
+}
+
+// ErrNoNextPage is returned by NextPageURL for the last page.
+var ErrNoNextPage = errors.New("no next page")
+
+// NextPageURL resolves the next link in meta, from a list of the
+// collection at path, into an absolute URL for GetURL. The API sends the
+// link as a bare query string, which only means something relative to the
+// collection it came from.
+func (c *Client) NextPageURL(path string, meta Meta) (string, error) {
+	next := meta.Pagination.Links.Next
+	if next == "" {
+		return "", ErrNoNextPage
+	}
+
+	link, err := url.Parse(next)
+	if err != nil {
+		return "", fmt.Errorf("invalid next page link %q: %w", next, err)
+	}
+	collection, err := url.Parse(path)
+	if err != nil {
+		return "", err
+	}
+
+	return c.baseURL.ResolveReference(collection).ResolveReference(link).String(), nil
+}
+
+// GetURL sends a GET to urlStr, either absolute or relative to the API
+// base URL, and decodes the response into v, reading Meta from it when v is
+// Paginated. Absolute URLs must be on the API host, as the request carries
+// the store's credentials.
+func (c *Client) GetURL(ctx context.Context, urlStr string, v interface{}) (*Response, error) {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	if u = c.baseURL.ResolveReference(u); u.Scheme != c.baseURL.Scheme || u.Host != c.baseURL.Host {
+		return nil, fmt.Errorf("refusing to request %s outside of %s", u.Redacted(), c.baseURL.Host)
+	}
+
+	req, err := c.NewRequest(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req, v)
+
+	var meta Meta
+	if p, ok := v.(Paginated); ok {
+		meta = p.GetPagination()
+	}
+	return newResponse(resp, meta), err
+}
+
+type Product struct {
+	ID                  int             `json:"id,omitempty"`
+	Name                string          `json:"name"`
+	Type                string          `json:"type"`
+	SKU                 string          `json:"sku,omitempty"`
+	Description         string          `json:"description,omitempty"`
+	Weight              float64         `json:"weight,omitempty"`
+	Width               float64         `json:"width,omitempty"`
+	Depth               float64         `json:"depth,omitempty"`
+	Height              float64         `json:"height,omitempty"`
+	Price               float64         `json:"price"`
+	CostPrice           float64         `json:"cost_price,omitempty"`
+	RetailPrice         float64         `json:"retail_price,omitempty"`
+	SalePrice           float64         `json:"sale_price,omitempty"`
+	MapPrice            float64         `json:"map_price,omitempty"`
+	TaxClassID          int             `json:"tax_class_id,omitempty"`
+	ProductTaxCode      string          `json:"product_tax_code,omitempty"`
+	Categories          []int           `json:"categories,omitempty"`
+	BrandID             int             `json:"brand_id,omitempty"`
+	InventoryLevel      int             `json:"inventory_level,omitempty"`
+	InventoryWarning    int             `json:"inventory_warning_level,omitempty"`
+	InventoryTracking   string          `json:"inventory_tracking,omitempty"`
+	FixedCostShipping   float64         `json:"fixed_cost_shipping_price,omitempty"`
+	IsFreeShipping      bool            `json:"is_free_shipping,omitempty"`
+	IsVisible           bool            `json:"is_visible,omitempty"`
+	IsFeatured          bool            `json:"is_featured,omitempty"`
+	RelatedProducts     []int           `json:"related_products,omitempty"`
+	Warranty            string          `json:"warranty,omitempty"`
+	BinPickingNumber    string          `json:"bin_picking_number,omitempty"`
+	Layout              string          `json:"layout_file,omitempty"`
+	UPC                 string          `json:"upc,omitempty"`
+	MPN                 string          `json:"mpn,omitempty"`
+	GTIN                string          `json:"gtin,omitempty"`
+	SearchKeywords      string          `json:"search_keywords,omitempty"`
+	Availability        string          `json:"availability,omitempty"`
+	AvailabilityDesc    string          `json:"availability_description,omitempty"`
+	GiftWrappingOpts    string          `json:"gift_wrapping_options_type,omitempty"`
+	GiftWrappingList    []int           `json:"gift_wrapping_options_list,omitempty"`
+	SortOrder           int             `json:"sort_order,omitempty"`
+	Condition           string          `json:"condition,omitempty"`
+	IsConditionShown    bool            `json:"is_condition_shown,omitempty"`
+	OrderQuantityMin    int             `json:"order_quantity_minimum,omitempty"`
+	OrderQuantityMax    int             `json:"order_quantity_maximum,omitempty"`
+	PageTitle           string          `json:"page_title,omitempty"`
+	MetaKeywords        []string        `json:"meta_keywords,omitempty"`
+	MetaDescription     string          `json:"meta_description,omitempty"`
+	DateCreated         string          `json:"date_created,omitempty"`
+	DateModified        string          `json:"date_modified,omitempty"`
+	ViewCount           int             `json:"view_count,omitempty"`
+	TotalSold           int             `json:"total_sold,omitempty"`
+	DateLastImported    string          `json:"date_last_imported,omitempty"`
+	PreorderReleaseDate string          `json:"preorder_release_date,omitempty"`
+	PreorderMessage     string          `json:"preorder_message,omitempty"`
+	IsPreorderOnly      bool            `json:"is_preorder_only,omitempty"`
+	IsPriceHidden       bool            `json:"is_price_hidden,omitempty"`
+	PriceHiddenLabel    string          `json:"price_hidden_label,omitempty"`
+	CustomURL           *CustomURL      `json:"custom_url,omitempty"`
+	BaseVariantID       int             `json:"base_variant_id,omitempty"`
+	OpenGraphType       string          `json:"open_graph_type,omitempty"`
+	OpenGraphTitle      string          `json:"open_graph_title,omitempty"`
+	OpenGraphDesc       string          `json:"open_graph_description,omitempty"`
+	Images              []ProductImage  `json:"images,omitempty"`
+	Videos              []ProductVideo  `json:"videos,omitempty"`
+	CustomFields        []CustomField   `json:"custom_fields,omitempty"`
+	BulkPricingRules    []PricingRule   `json:"bulk_pricing_rules,omitempty"`
+	Variants            []Variant       `json:"variants,omitempty"`
+	Options             []ProductOption `json:"options,omitempty"`
+	Modifiers           []Modifier      `json:"modifiers,omitempty"`
+	Reviews             []Review        `json:"reviews,omitempty"`
+	ComplexRules        []ComplexRule   `json:"complex_rules,omitempty"`
+}
+
+const (
+	ProductTypePhysical = "physical"
+
+	// ProductTypeDigital marks a download. The v3 API has no download
+	// fields: the files are managed in the control panel, so Product
+	// already carries every field the API returns for a digital product.
+	ProductTypeDigital = "digital"
+)
+
+// withoutReadOnly returns a copy of p without TotalSold and
+// DateLastImported, which the store sets itself, so a product that was read
+// can be written back as is.
+func (p *Product) withoutReadOnly() *Product {
+	writable := *p
+	writable.TotalSold = 0
+	writable.DateLastImported = ""
+	return &writable
+}
+
+func productsWithoutReadOnly(products []Product) []Product {
+	writable := make([]Product, len(products))
+	for i := range products {
+		writable[i] = *products[i].withoutReadOnly()
+	}
+	return writable
+}
+
+// Values for QueryParams.Sort when listing products. total_sold, with
+// Direction "desc", lists best sellers first. The store sets the matching
+// TotalSold and DateLastImported fields itself, so they are only read.
+const (
+	ProductSortID               = "id"
+	ProductSortName             = "name"
+	ProductSortSKU              = "sku"
+	ProductSortPrice            = "price"
+	ProductSortDateModified     = "date_modified"
+	ProductSortDateLastImported = "date_last_imported"
+	ProductSortInventoryLevel   = "inventory_level"
+	ProductSortIsVisible        = "is_visible"
+	ProductSortTotalSold        = "total_sold"
+)
+
+const (
+	ConditionNew         = "New"
+	ConditionUsed        = "Used"
+	ConditionRefurbished = "Refurbished"
+)
+
+const (
+	AvailabilityAvailable = "available"
+	AvailabilityDisabled  = "disabled"
+	AvailabilityPreorder  = "preorder"
+)
+
+const (
+	InventoryTrackingNone    = "none"
+	InventoryTrackingProduct = "product"
+	InventoryTrackingVariant = "variant"
+)
+
+const (
+	maxProductNameLength = 250
+	maxProductSKULength  = 255
+)
+
+func (p *Product) Validate() error {
+	var errs []error
+
+	if p.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	} else if len(p.Name) > maxProductNameLength {
+		errs = append(errs, fmt.Errorf("name exceeds %d characters", maxProductNameLength))
+	}
+
+	switch p.Type {
+	case "":
+		errs = append(errs, errors.New("type is required"))
+	case ProductTypePhysical, ProductTypeDigital:
+	default:
+		errs = append(errs, fmt.Errorf("type %q is not one of %s, %s", p.Type, ProductTypePhysical, ProductTypeDigital))
+	}
+
+	if p.Price < 0 {
+		errs = append(errs, fmt.Errorf("price %.2f is negative", p.Price))
+	}
+
+	if p.CostPrice < 0 || p.RetailPrice < 0 || p.SalePrice < 0 || p.MapPrice < 0 {
+		errs = append(errs, errors.New("cost, retail, sale and map prices must not be negative"))
+	}
+
+	if len(p.SKU) > maxProductSKULength {
+		errs = append(errs, fmt.Errorf("sku exceeds %d characters", maxProductSKULength))
+	}
+
+	if p.Weight < 0 || p.Width < 0 || p.Depth < 0 || p.Height < 0 {
+		errs = append(errs, errors.New("weight and dimensions must not be negative"))
+	}
+
+	if p.InventoryLevel < 0 || p.InventoryWarning < 0 {
+		errs = append(errs, errors.New("inventory levels must not be negative"))
+	}
+
+	// An unknown value is accepted by the API and silently tracks nothing
+	switch p.InventoryTracking {
+	case "", InventoryTrackingNone, InventoryTrackingProduct, InventoryTrackingVariant:
+	default:
+		errs = append(errs, fmt.Errorf("inventory tracking %q is not one of %s, %s, %s", p.InventoryTracking, InventoryTrackingNone, InventoryTrackingProduct, InventoryTrackingVariant))
+	}
+
+	if p.OrderQuantityMax > 0 && p.OrderQuantityMax < p.OrderQuantityMin {
+		errs = append(errs, fmt.Errorf("order quantity maximum %d is below minimum %d", p.OrderQuantityMax, p.OrderQuantityMin))
+	}
+
+	seenCategories := make(map[int]bool, len(p.Categories))
+	for _, categoryID := range p.Categories {
+		if categoryID <= 0 {
+			errs = append(errs, fmt.Errorf("category id %d is invalid", categoryID))
+		} else if seenCategories[categoryID] {
+			errs = append(errs, fmt.Errorf("category id %d is repeated", categoryID))
+		}
+		seenCategories[categoryID] = true
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid product %q: %w", p.Name, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// NormalizeCategories drops repeated category IDs, keeping the first of
+// each so the primary category stays first.
+func (p *Product) NormalizeCategories() {
+	seen := make(map[int]bool, len(p.Categories))
+	categories := p.Categories[:0]
+	for _, categoryID := range p.Categories {
+		if !seen[categoryID] {
+			seen[categoryID] = true
+			categories = append(categories, categoryID)
+		}
+	}
+	p.Categories = categories
+}
+
+// NormalizePrices rounds every price field to cents and reorders them into
+// the relationships the API and storefront expect: cost ≤ price ≤ retail
+// and sale ≤ price, with a sale never advertised below the MAP price. The
+// MAP price itself is never changed; a sale it leaves no room for is
+// dropped. Zero means unset and is left alone. Run it after computing
+// prices from each other, since separately rounded multiples can cross.
+func (p *Product) NormalizePrices() {
+	p.Price = roundCents(p.Price)
+	p.CostPrice = roundCents(p.CostPrice)
+	p.RetailPrice = roundCents(p.RetailPrice)
+	p.SalePrice = roundCents(p.SalePrice)
+	p.MapPrice = roundCents(p.MapPrice)
+
+	if p.CostPrice > p.Price {
+		p.CostPrice = p.Price
+	}
+	if p.RetailPrice > 0 && p.RetailPrice < p.Price {
+		p.RetailPrice = p.Price
+	}
+	if p.SalePrice > p.Price {
+		p.SalePrice = p.Price
+	}
+	if p.SalePrice > 0 && p.SalePrice < p.MapPrice {
+		p.SalePrice = p.MapPrice
+		if p.SalePrice > p.Price {
+			// No sale fits under the price without breaking MAP
+			p.SalePrice = 0
+		}
+	}
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// Warnings reports settings the API accepts but that probably do not do what
+// was meant. Unlike Validate's errors they need not block a create.
+func (p *Product) Warnings() []string {
+	var warnings []string
+
+	if p.InventoryTracking == InventoryTrackingVariant && len(p.Variants) == 0 {
+		warnings = append(warnings, "inventory is tracked by variant but the product has no variants, so only its base variant is counted")
+	}
+
+	return warnings
+}
+
+type ProductResponse struct {
+	Data Product `json:"data"`
+	Meta Meta    `json:"meta"`
+
+	// ETag is the version of Data the store reported, if any. Pass it to
+	// WithIfMatch to make a later update conditional on it.
+	ETag string `json:"-"`
+}
+
+type ProductsResponse struct {
+	Data []Product `json:"data"`
+	Meta Meta      `json:"meta"`
+}
+
+// NextPage fetches the page following r. It returns nil with no error when r
+// is the last page.
+func (r *ProductsResponse) NextPage(ctx context.Context, s *ProductsService) (*ProductsResponse, error) {
+	next := new(ProductsResponse)
+	if ok, err := s.client.nextPage(ctx, "catalog/products", r.Meta, next); !ok {
+		return nil, err
+	}
+	return next, nil
+}
+
+type ProductImage struct {
+	ID           int    `json:"id,omitempty"`
+	ProductID    int    `json:"product_id,omitempty"`
+	IsThumbnail  bool   `json:"is_thumbnail,omitempty"`
+	SortOrder    int    `json:"sort_order,omitempty"`
+	Description  string `json:"description,omitempty"`
+	ImageFile    string `json:"image_file,omitempty"`
+	ImageURL     string `json:"image_url,omitempty"`
+	URLZoom      string `json:"url_zoom,omitempty"`
+	URLStandard  string `json:"url_standard,omitempty"`
+	URLThumbnail string `json:"url_thumbnail,omitempty"`
+	URLTiny      string `json:"url_tiny,omitempty"`
+	DateModified string `json:"date_modified,omitempty"`
+}
+
+type ProductVideo struct {
+	ID          int    `json:"id,omitempty"`
+	ProductID   int    `json:"product_id,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	SortOrder   int    `json:"sort_order,omitempty"`
+	Type        string `json:"type,omitempty"`
+	VideoID     string `json:"video_id,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+type ProductImageResponse struct {
+	Data ProductImage `json:"data"`
+	Meta Meta         `json:"meta"`
+}
+
+type ProductImagesResponse struct {
+	Data []ProductImage `json:"data"`
+	Meta Meta           `json:"meta"`
+}
+
+type ProductVideoResponse struct {
+	Data ProductVideo `json:"data"`
+	Meta Meta         `json:"meta"`
+}
+
+type ProductVideosResponse struct {
+	Data []ProductVideo `json:"data"`
+	Meta Meta           `json:"meta"`
+}
+
+type CustomURL struct {
+	URL          string `json:"url,omitempty"`
+	IsCustomized bool   `json:"is_customized,omitempty"`
+}
+
+type CustomField struct {
+	ID        int    `json:"id,omitempty"`
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	ProductID int    `json:"product_id,omitempty"`
+}
+
+type PricingRule struct {
+	ID          int     `json:"id,omitempty"`
+	QuantityMin int     `json:"quantity_min"`
+	QuantityMax int     `json:"quantity_max,omitempty"`
+	Type        string  `json:"type"`
+	Amount      float64 `json:"amount"`
+	ProductID   int     `json:"product_id,omitempty"`
+}
+
+type Variant struct {
+	ID                     int           `json:"id,omitempty"`
+	ProductID              int           `json:"product_id,omitempty"`
+	SKU                    string        `json:"sku,omitempty"`
+	Price                  float64       `json:"price,omitempty"`
+	CostPrice              float64       `json:"cost_price,omitempty"`
+	SalePrice              float64       `json:"sale_price,omitempty"`
+	RetailPrice            float64       `json:"retail_price,omitempty"`
+	Weight                 float64       `json:"weight,omitempty"`
+	Width                  float64       `json:"width,omitempty"`
+	Height                 float64       `json:"height,omitempty"`
+	Depth                  float64       `json:"depth,omitempty"`
+	IsFree                 bool          `json:"is_free_shipping,omitempty"`
+	FixedCostShippingPrice float64       `json:"fixed_cost_shipping_price,omitempty"`
+	PurchasingDisabled     bool          `json:"purchasing_disabled,omitempty"`
+	PurchasingDisabledMsg  string        `json:"purchasing_disabled_message,omitempty"`
+	ImageURL               string        `json:"image_url,omitempty"`
+	UPC                    string        `json:"upc,omitempty"`
+	MPN                    string        `json:"mpn,omitempty"`
+	GTIN                   string        `json:"gtin,omitempty"`
+	InventoryLevel         int           `json:"inventory_level,omitempty"`
+	InventoryWarningLevel  int           `json:"inventory_warning_level,omitempty"`
+	BinPickingNumber       string        `json:"bin_picking_number,omitempty"`
+	OptionValues           []OptionValue `json:"option_values"`
+}
+
+type OptionValue struct {
+	ID        int                    `json:"id,omitempty"`
+	OptionID  int                    `json:"option_id"`
+	Label     string                 `json:"label,omitempty"`
+	SortOrder int                    `json:"sort_order,omitempty"`
+	Value     string                 `json:"value,omitempty"`
+	IsDefault bool                   `json:"is_default,omitempty"`
+	ValueData map[string]interface{} `json:"value_data,omitempty"`
+}
+
+type ProductOption struct {
+	ID           int           `json:"id,omitempty"`
+	ProductID    int           `json:"product_id,omitempty"`
+	DisplayName  string        `json:"display_name"`
+	Type         string        `json:"type"`
+	Config       *OptionConfig `json:"config,omitempty"`
+	OptionValues []OptionValue `json:"option_values,omitempty"`
+}
+
+type OptionConfig struct {
+	DefaultValue      string   `json:"default_value,omitempty"`
+	CheckedByDefault  bool     `json:"checked_by_default,omitempty"`
+	CheckboxLabel     string   `json:"checkbox_label,omitempty"`
+	DateLimited       bool     `json:"date_limited,omitempty"`
+	DateLimitMode     string   `json:"date_limit_mode,omitempty"`
+	DateEarliestValue string   `json:"date_earliest_value,omitempty"`
+	DateLatestValue   string   `json:"date_latest_value,omitempty"`
+	FileTypes         []string `json:"file_types_supported,omitempty"`
+	FileMaxSize       int      `json:"file_max_size,omitempty"`
+	TextMinLength     int      `json:"text_min_length,omitempty"`
+	TextMaxLength     int      `json:"text_max_length,omitempty"`
+	TextCharsLimit    bool     `json:"text_characters_limited,omitempty"`
+	NumberLimited     bool     `json:"number_limited,omitempty"`
+	NumberLimitMode   string   `json:"number_limit_mode,omitempty"`
+	NumberMin         float64  `json:"number_lowest_value,omitempty"`
+	NumberMax         float64  `json:"number_highest_value,omitempty"`
+	NumberIntOnly     bool     `json:"number_integers_only,omitempty"`
+}
+
+type Modifier struct {
+	ID           int             `json:"id,omitempty"`
+	ProductID    int             `json:"product_id,omitempty"`
+	Name         string          `json:"name"`
+	DisplayName  string          `json:"display_name"`
+	Type         string          `json:"type"`
+	Required     bool            `json:"required"`
+	Config       *ModifierConfig `json:"config,omitempty"`
+	OptionValues []OptionValue   `json:"option_values,omitempty"`
+}
+
+type ModifierConfig struct {
+	DefaultValue          string   `json:"default_value,omitempty"`
 	CheckedByDefault      bool     `json:"checked_by_default,omitempty"`
 	CheckboxLabel         string   `json:"checkbox_label,omitempty"`
 	DateLimited           bool     `json:"date_limited,omitempty"`
@@ -576,7 +2088,7 @@ type ModifierConfig struct {
 	FileMaxSize           int      `json:"file_max_size,omitempty"`
 	TextMinLength         int      `json:"text_min_length,omitempty"`
 	TextMaxLength         int      `json:"text_max_length,omitempty"`
-	TextCharsLimit        int      `json:"text_characters_limited,omitempty"`
+	TextCharsLimit        bool     `json:"text_characters_limited,omitempty"`
 	NumberLimited         bool     `json:"number_limited,omitempty"`
 	NumberLimitMode       string   `json:"number_limit_mode,omitempty"`
 	NumberMin             float64  `json:"number_lowest_value,omitempty"`
@@ -586,246 +2098,2156 @@ type ModifierConfig struct {
 	ProductListAdjustName string   `json:"product_list_adjusts_pricing,omitempty"`
 }
 
-type Category struct {
-	ID                 int        `json:"id,omitempty"`
-	ParentID           int        `json:"parent_id"`
-	Name               string     `json:"name"`
-	Description        string     `json:"description,omitempty"`
-	Views              int        `json:"views,omitempty"`
-	SortOrder          int        `json:"sort_order,omitempty"`
-	PageTitle          string     `json:"page_title,omitempty"`
-	MetaKeywords       []string   `json:"meta_keywords,omitempty"`
-	MetaDescription    string     `json:"meta_description,omitempty"`
-	LayoutFile         string     `json:"layout_file,omitempty"`
-	IsVisible          bool       `json:"is_visible,omitempty"`
-	DefaultProductSort string     `json:"default_product_sort,omitempty"`
-	ImageURL           string     `json:"image_url,omitempty"`
-	CustomURL          *CustomURL `json:"custom_url,omitempty"`
+type Category struct {
+	ID                 int        `json:"id,omitempty"`
+	ParentID           int        `json:"parent_id"`
+	Name               string     `json:"name"`
+	Description        string     `json:"description,omitempty"`
+	Views              int        `json:"views,omitempty"`
+	SortOrder          int        `json:"sort_order,omitempty"`
+	PageTitle          string     `json:"page_title,omitempty"`
+	MetaKeywords       []string   `json:"meta_keywords,omitempty"`
+	MetaDescription    string     `json:"meta_description,omitempty"`
+	LayoutFile         string     `json:"layout_file,omitempty"`
+	IsVisible          bool       `json:"is_visible,omitempty"`
+	DefaultProductSort string     `json:"default_product_sort,omitempty"`
+	ImageURL           string     `json:"image_url,omitempty"`
+	CustomURL          *CustomURL `json:"custom_url,omitempty"`
+}
+
+type Brand struct {
+	ID              int        `json:"id,omitempty"`
+	Name            string     `json:"name"`
+	PageTitle       string     `json:"page_title,omitempty"`
+	MetaKeywords    []string   `json:"meta_keywords,omitempty"`
+	MetaDescription string     `json:"meta_description,omitempty"`
+	ImageURL        string     `json:"image_url,omitempty"`
+	SearchKeywords  string     `json:"search_keywords,omitempty"`
+	CustomURL       *CustomURL `json:"custom_url,omitempty"`
+}
+
+type Review struct {
+	ID           int    `json:"id,omitempty"`
+	ProductID    int    `json:"product_id,omitempty"`
+	Title        string `json:"title"`
+	Text         string `json:"text"`
+	Status       string `json:"status,omitempty"`
+	Rating       int    `json:"rating"`
+	Email        string `json:"email,omitempty"`
+	Name         string `json:"name,omitempty"`
+	DateCreated  string `json:"date_created,omitempty"`
+	DateModified string `json:"date_modified,omitempty"`
+}
+
+type ComplexRule struct {
+	ID            int             `json:"id,omitempty"`
+	ProductID     int             `json:"product_id,omitempty"`
+	Enabled       bool            `json:"enabled"`
+	Stop          bool            `json:"stop"`
+	Purchasing    bool            `json:"purchasing_disabled"`
+	PurchasingMsg string          `json:"purchasing_disabled_message,omitempty"`
+	Adjusters     *RuleAdjusters  `json:"price_adjuster,omitempty"`
+	Conditions    []RuleCondition `json:"conditions"`
+	SortOrder     int             `json:"sort_order,omitempty"`
+}
+
+type RuleAdjusters struct {
+	Type   string  `json:"adjuster"`
+	Amount float64 `json:"adjuster_value,omitempty"`
+}
+
+type RuleCondition struct {
+	OptionID int    `json:"product_option_id"`
+	ValueID  int    `json:"product_option_value_id"`
+	Rule     string `json:"rule,omitempty"`
+}
+
+type Channel struct {
+	ID                  int    `json:"id,omitempty"`
+	Name                string `json:"name"`
+	Type                string `json:"type"`
+	Platform            string `json:"platform,omitempty"`
+	Status              string `json:"status"`
+	IsListable          bool   `json:"is_listable"`
+	IsVisible           bool   `json:"is_visible"`
+	ExternalID          string `json:"external_id,omitempty"`
+	IsExternallyManaged bool   `json:"is_externally_managed,omitempty"`
+	DateCreated         string `json:"date_created,omitempty"`
+	DateModified        string `json:"date_modified,omitempty"`
+}
+
+type Metafield struct {
+	ID           int    `json:"id,omitempty"`
+	Key          string `json:"key"`
+	Value        string `json:"value"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   int    `json:"resource_id"`
+	Description  string `json:"description,omitempty"`
+	Namespace    string `json:"namespace"`
+	Permission   string `json:"permission,omitempty"`
+	DateCreated  string `json:"date_created,omitempty"`
+	DateModified string `json:"date_modified,omitempty"`
+}
+
+const (
+	MetafieldPermissionAppOnly          = "app_only"
+	MetafieldPermissionRead             = "read"
+	MetafieldPermissionWrite            = "write"
+	MetafieldPermissionReadAndSFAccess  = "read_and_sf_access"
+	MetafieldPermissionWriteAndSFAccess = "write_and_sf_access"
+)
+
+const (
+	maxMetafieldNamespaceLength   = 64
+	maxMetafieldKeyLength         = 64
+	maxMetafieldValueLength       = 65535
+	maxMetafieldDescriptionLength = 255
+)
+
+// Normalize trims the namespace and key and lowercases the permission,
+// which the API would otherwise reject or store as given.
+func (m *Metafield) Normalize() {
+	m.Namespace = strings.TrimSpace(m.Namespace)
+	m.Key = strings.TrimSpace(m.Key)
+	m.Permission = strings.ToLower(strings.TrimSpace(m.Permission))
+}
+
+// Validate checks the metafield against the API's limits, so a bad one
+// fails with a clear message before it is sent. Run Normalize first to
+// accept stray whitespace and case. MetafieldsService does not call it.
+func (m *Metafield) Validate() error {
+	var errs []error
+
+	if m.Namespace == "" {
+		errs = append(errs, errors.New("namespace is required"))
+	} else if len(m.Namespace) > maxMetafieldNamespaceLength {
+		errs = append(errs, fmt.Errorf("namespace exceeds %d characters", maxMetafieldNamespaceLength))
+	}
+
+	if m.Key == "" {
+		errs = append(errs, errors.New("key is required"))
+	} else if len(m.Key) > maxMetafieldKeyLength {
+		errs = append(errs, fmt.Errorf("key exceeds %d characters", maxMetafieldKeyLength))
+	}
+
+	if len(m.Value) > maxMetafieldValueLength {
+		errs = append(errs, fmt.Errorf("value is %d bytes, over the %d limit", len(m.Value), maxMetafieldValueLength))
+	}
+
+	if len(m.Description) > maxMetafieldDescriptionLength {
+		errs = append(errs, fmt.Errorf("description exceeds %d characters", maxMetafieldDescriptionLength))
+	}
+
+	switch m.Permission {
+	case MetafieldPermissionAppOnly, MetafieldPermissionRead, MetafieldPermissionWrite,
+		MetafieldPermissionReadAndSFAccess, MetafieldPermissionWriteAndSFAccess:
+	case "":
+		errs = append(errs, errors.New("permission is required"))
+	default:
+		errs = append(errs, fmt.Errorf("permission %q is not one of %s, %s, %s, %s, %s", m.Permission,
+			MetafieldPermissionAppOnly, MetafieldPermissionRead, MetafieldPermissionWrite,
+			MetafieldPermissionReadAndSFAccess, MetafieldPermissionWriteAndSFAccess))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid metafield %q: %w", m.Namespace+"."+m.Key, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+type Summary struct {
+	InventoryLevel   int     `json:"inventory_level"`
+	InventoryWarning int     `json:"inventory_warning_level"`
+	PrimaryCategory  int     `json:"primary_category_id"`
+	TotalSold        int     `json:"total_sold"`
+	PrimaryImage     *Image  `json:"primary_image,omitempty"`
+	Availability     string  `json:"availability"`
+	AvgRating        float64 `json:"rating_average"`
+	NumReviews       int     `json:"number_of_reviews"`
+}
+
+type Image struct {
+	ID           int    `json:"id,omitempty"`
+	ProductID    int    `json:"product_id,omitempty"`
+	URLThumbnail string `json:"url_thumbnail,omitempty"`
+	URLStandard  string `json:"url_standard,omitempty"`
+}
+
+type CategoryResponse struct {
+	Data Category `json:"data"`
+	Meta Meta     `json:"meta"`
+}
+
+type CategoriesResponse struct {
+	Data []Category `json:"data"`
+	Meta Meta       `json:"meta"`
+}
+
+// NextPage fetches the page following r. It returns nil with no error when r
+// is the last page.
+func (r *CategoriesResponse) NextPage(ctx context.Context, s *CategoriesService) (*CategoriesResponse, error) {
+	next := new(CategoriesResponse)
+	if ok, err := s.client.nextPage(ctx, "catalog/categories", r.Meta, next); !ok {
+		return nil, err
+	}
+	return next, nil
+}
+
+type BrandResponse struct {
+	Data Brand `json:"data"`
+	Meta Meta  `json:"meta"`
+}
+
+type BrandsResponse struct {
+	Data []Brand `json:"data"`
+	Meta Meta    `json:"meta"`
+}
+
+// NextPage fetches the page following r. It returns nil with no error when r
+// is the last page.
+func (r *BrandsResponse) NextPage(ctx context.Context, s *BrandsService) (*BrandsResponse, error) {
+	next := new(BrandsResponse)
+	if ok, err := s.client.nextPage(ctx, "catalog/brands", r.Meta, next); !ok {
+		return nil, err
+	}
+	return next, nil
+}
+
+type VariantResponse struct {
+	Data Variant `json:"data"`
+	Meta Meta    `json:"meta"`
+}
+
+type VariantsResponse struct {
+	Data []Variant `json:"data"`
+	Meta Meta      `json:"meta"`
+}
+
+type OptionValueResponse struct {
+	Data OptionValue `json:"data"`
+	Meta Meta        `json:"meta"`
+}
+
+type OptionValuesResponse struct {
+	Data []OptionValue `json:"data"`
+	Meta Meta          `json:"meta"`
+}
+
+type ProductOptionResponse struct {
+	Data ProductOption `json:"data"`
+	Meta Meta          `json:"meta"`
+}
+
+type ProductOptionsResponse struct {
+	Data []ProductOption `json:"data"`
+	Meta Meta            `json:"meta"`
+}
+
+type ModifierResponse struct {
+	Data Modifier `json:"data"`
+	Meta Meta     `json:"meta"`
+}
+
+type ModifiersResponse struct {
+	Data []Modifier `json:"data"`
+	Meta Meta       `json:"meta"`
+}
+
+type ReviewResponse struct {
+	Data Review `json:"data"`
+	Meta Meta   `json:"meta"`
+}
+
+type ReviewsResponse struct {
+	Data []Review `json:"data"`
+	Meta Meta     `json:"meta"`
+}
+
+type ComplexRuleResponse struct {
+	Data ComplexRule `json:"data"`
+	Meta Meta        `json:"meta"`
+}
+
+type ComplexRulesResponse struct {
+	Data []ComplexRule `json:"data"`
+	Meta Meta          `json:"meta"`
+}
+
+type CustomFieldResponse struct {
+	Data CustomField `json:"data"`
+	Meta Meta        `json:"meta"`
+}
+
+type CustomFieldsResponse struct {
+	Data []CustomField `json:"data"`
+	Meta Meta          `json:"meta"`
+}
+
+type PricingRuleResponse struct {
+	Data PricingRule `json:"data"`
+	Meta Meta        `json:"meta"`
+}
+
+type PricingRulesResponse struct {
+	Data []PricingRule `json:"data"`
+	Meta Meta          `json:"meta"`
+}
+
+type ChannelResponse struct {
+	Data Channel `json:"data"`
+	Meta Meta    `json:"meta"`
+}
+
+type ChannelsResponse struct {
+	Data []Channel `json:"data"`
+	Meta Meta      `json:"meta"`
+}
+
+type MetafieldResponse struct {
+	Data Metafield `json:"data"`
+	Meta Meta      `json:"meta"`
+}
+
+type MetafieldsResponse struct {
+	Data []Metafield `json:"data"`
+	Meta Meta        `json:"meta"`
+}
+
+type SummaryResponse struct {
+	Data Summary `json:"data"`
+	Meta Meta    `json:"meta"`
+}
+
+type BrandsService struct {
+	client   *Client
+	resource crud[Brand, BrandResponse, BrandsResponse]
+	listDefaults
+}
+
+func (s *BrandsService) ListContext(ctx context.Context, params *QueryParams) (*BrandsResponse, error) {
+	brandsResponse, _, err := s.ListWithResponseContext(ctx, params)
+	return brandsResponse, err
+}
+
+func (s *BrandsService) ListWithResponseContext(ctx context.Context, params *QueryParams) (*BrandsResponse, *Response, error) {
+	return s.resource.list(ctx, s.withDefaults(params))
+}
+
+func (s *BrandsService) ListAllContext(ctx context.Context, params *QueryParams) ([]Brand, error) {
+	return listAll(ctx, s.withDefaults(params), func(ctx context.Context, params *QueryParams) ([]Brand, Meta, error) {
+		brandsResponse, err := s.ListContext(ctx, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return brandsResponse.Data, brandsResponse.Meta, nil
+	})
+}
+
+func (s *BrandsService) GetContext(ctx context.Context, id int, params *QueryParams) (*BrandResponse, error) {
+	brandResponse, _, err := s.GetWithResponseContext(ctx, id, params)
+	return brandResponse, err
+}
+
+func (s *BrandsService) GetWithResponseContext(ctx context.Context, id int, params *QueryParams) (*BrandResponse, *Response, error) {
+	brandResponse, resp, err := s.resource.get(ctx, id, params)
+	if err == nil && brandResponse.Data.ID == 0 {
+		err = emptyGet(fmt.Sprintf("%s/%d", s.resource.path, id))
+	}
+	return brandResponse, resp, err
+}
+
+func (s *BrandsService) CreateContext(ctx context.Context, brand *Brand) (*BrandResponse, error) {
+	brandResponse, _, err := s.CreateWithResponseContext(ctx, brand)
+	return brandResponse, err
+}
+
+func (s *BrandsService) CreateWithResponseContext(ctx context.Context, brand *Brand) (*BrandResponse, *Response, error) {
+	return s.resource.create(ctx, brand)
+}
+
+func (s *BrandsService) UpdateContext(ctx context.Context, id int, brand *Brand) (*BrandResponse, error) {
+	brandResponse, _, err := s.UpdateWithResponseContext(ctx, id, brand)
+	return brandResponse, err
+}
+
+func (s *BrandsService) UpdateWithResponseContext(ctx context.Context, id int, brand *Brand) (*BrandResponse, *Response, error) {
+	return s.resource.update(ctx, id, brand)
+}
+
+func (s *BrandsService) GetByNameContext(ctx context.Context, name string) (*BrandResponse, error) {
+	brandsResponse, err := s.ListContext(ctx, &QueryParams{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, brand := range brandsResponse.Data {
+		if brand.Name == name {
+			return &BrandResponse{Data: brand, Meta: brandsResponse.Meta}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("brand %q: %w", name, ErrNotFound)
+}
+
+// FindOrCreateContext returns the brand with brand.Name, creating it when
+// none exists. The boolean reports whether a new brand was created.
+func (s *BrandsService) FindOrCreateContext(ctx context.Context, brand *Brand) (*BrandResponse, bool, error) {
+	existing, err := s.GetByNameContext(ctx, brand.Name)
+	if err == nil {
+		return existing, false, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, false, err
+	}
+
+	created, err := s.CreateContext(ctx, brand)
+	return created, err == nil, err
+}
+
+// UploadImageContext replaces the brand image with file contents rather than
+// a URL. The upload endpoint only echoes the image URL, so the brand is
+// re-read to return it with the new CDN URL.
+func (s *BrandsService) UploadImageContext(ctx context.Context, brandID int, filename string, file io.Reader) (*BrandResponse, error) {
+	path := fmt.Sprintf("catalog/brands/%d/image", brandID)
+
+	req, err := s.client.newUploadRequest(ctx, path, nil, filename, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.client.Do(req, nil); err != nil {
+		return nil, err
+	}
+
+	return s.GetContext(ctx, brandID, nil)
+}
+
+// DeleteManyContext deletes brands in chunks, returning the error for each
+// ID that could not be deleted. Products keep existing but lose the brand.
+func (s *BrandsService) DeleteManyContext(ctx context.Context, ids []int) map[int]error {
+	return s.client.deleteMany(ctx, "catalog/brands", ids)
+}
+
+func (s *BrandsService) DeleteContext(ctx context.Context, id int) error {
+	return s.resource.delete(ctx, id)
+}
+
+type CategoriesService struct {
+	client   *Client
+	resource crud[Category, CategoryResponse, CategoriesResponse]
+	listDefaults
+}
+
+func (s *CategoriesService) ListContext(ctx context.Context, params *QueryParams) (*CategoriesResponse, error) {
+	categoriesResponse, _, err := s.ListWithResponseContext(ctx, params)
+	return categoriesResponse, err
+}
+
+func (s *CategoriesService) ListWithResponseContext(ctx context.Context, params *QueryParams) (*CategoriesResponse, *Response, error) {
+	return s.resource.list(ctx, s.withDefaults(params))
+}
+
+func (s *CategoriesService) ListAllContext(ctx context.Context, params *QueryParams) ([]Category, error) {
+	return listAll(ctx, s.withDefaults(params), func(ctx context.Context, params *QueryParams) ([]Category, Meta, error) {
+		categoriesResponse, err := s.ListContext(ctx, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return categoriesResponse.Data, categoriesResponse.Meta, nil
+	})
+}
+
+func (s *CategoriesService) GetContext(ctx context.Context, id int, params *QueryParams) (*CategoryResponse, error) {
+	categoryResponse, _, err := s.GetWithResponseContext(ctx, id, params)
+	return categoryResponse, err
+}
+
+func (s *CategoriesService) GetWithResponseContext(ctx context.Context, id int, params *QueryParams) (*CategoryResponse, *Response, error) {
+	categoryResponse, resp, err := s.resource.get(ctx, id, params)
+	if err == nil && categoryResponse.Data.ID == 0 {
+		err = emptyGet(fmt.Sprintf("%s/%d", s.resource.path, id))
+	}
+	return categoryResponse, resp, err
+}
+
+func (s *CategoriesService) CreateContext(ctx context.Context, category *Category) (*CategoryResponse, error) {
+	categoryResponse, _, err := s.CreateWithResponseContext(ctx, category)
+	return categoryResponse, err
+}
+
+func (s *CategoriesService) CreateWithResponseContext(ctx context.Context, category *Category) (*CategoryResponse, *Response, error) {
+	return s.resource.create(ctx, category)
+}
+
+func (s *CategoriesService) UpdateContext(ctx context.Context, id int, category *Category) (*CategoryResponse, error) {
+	categoryResponse, _, err := s.UpdateWithResponseContext(ctx, id, category)
+	return categoryResponse, err
+}
+
+func (s *CategoriesService) UpdateWithResponseContext(ctx context.Context, id int, category *Category) (*CategoryResponse, *Response, error) {
+	return s.resource.update(ctx, id, category)
+}
+
+func (s *CategoriesService) GetByNameContext(ctx context.Context, name string, parentID int) (*CategoryResponse, error) {
+	categoriesResponse, err := s.ListContext(ctx, &QueryParams{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, category := range categoriesResponse.Data {
+		if category.Name == name && category.ParentID == parentID {
+			return &CategoryResponse{Data: category, Meta: categoriesResponse.Meta}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("category %q under parent %d: %w", name, parentID, ErrNotFound)
+}
+
+// FindOrCreateContext returns the category named category.Name under
+// category.ParentID, creating it when none exists. The boolean reports
+// whether a new category was created.
+func (s *CategoriesService) FindOrCreateContext(ctx context.Context, category *Category) (*CategoryResponse, bool, error) {
+	existing, err := s.GetByNameContext(ctx, category.Name, category.ParentID)
+	if err == nil {
+		return existing, false, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, false, err
+	}
+
+	created, err := s.CreateContext(ctx, category)
+	return created, err == nil, err
+}
+
+// ReorderChildrenContext sets the sort order of parentID's children to
+// follow orderedChildIDs in a single batch update. Children left out of
+// orderedChildIDs keep their relative order after the listed ones. It
+// returns the children in their new order.
+func (s *CategoriesService) ReorderChildrenContext(ctx context.Context, parentID int, orderedChildIDs []int) ([]Category, error) {
+	all, err := s.ListAllContext(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	children := make(map[int]Category)
+	var unlisted []Category
+	for _, category := range all {
+		if category.ParentID == parentID {
+			children[category.ID] = category
+		}
+	}
+
+	listed := make(map[int]bool, len(orderedChildIDs))
+	ordered := make([]Category, 0, len(children))
+	for _, id := range orderedChildIDs {
+		child, ok := children[id]
+		if !ok {
+			return nil, fmt.Errorf("category %d is not a child of %d", id, parentID)
+		}
+		if listed[id] {
+			return nil, fmt.Errorf("category %d is listed more than once", id)
+		}
+		listed[id] = true
+		ordered = append(ordered, child)
+	}
+
+	for _, child := range children {
+		if !listed[child.ID] {
+			unlisted = append(unlisted, child)
+		}
+	}
+	sort.SliceStable(unlisted, func(i, j int) bool {
+		if unlisted[i].SortOrder != unlisted[j].SortOrder {
+			return unlisted[i].SortOrder < unlisted[j].SortOrder
+		}
+		return unlisted[i].ID < unlisted[j].ID
+	})
+	ordered = append(ordered, unlisted...)
+
+	type categorySortOrder struct {
+		CategoryID int `json:"category_id"`
+		SortOrder  int `json:"sort_order"`
+	}
+
+	updates := make([]categorySortOrder, len(ordered))
+	for i := range ordered {
+		ordered[i].SortOrder = i
+		updates[i] = categorySortOrder{CategoryID: ordered[i].ID, SortOrder: i}
+	}
+
+	req, err := s.client.NewRequest(ctx, "PUT", "catalog/trees/categories", updates)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.client.Do(req, nil); err != nil {
+		return nil, err
+	}
+
+	return ordered, nil
+}
+
+// ProductSortOrder is a product's position on one category's page; product
+// sort_order only applies to listings not scoped to a category.
+type ProductSortOrder struct {
+	ProductID int `json:"product_id"`
+	SortOrder int `json:"sort_order"`
+}
+
+type ProductSortOrdersResponse struct {
+	Data []ProductSortOrder `json:"data"`
+	Meta Meta               `json:"meta"`
+}
+
+func (s *CategoriesService) GetProductSortOrderContext(ctx context.Context, categoryID int) (*ProductSortOrdersResponse, error) {
+	path := fmt.Sprintf("catalog/categories/%d/products/sort-order", categoryID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sortOrdersResponse := new(ProductSortOrdersResponse)
+	_, err = s.client.Do(req, sortOrdersResponse)
+	return sortOrdersResponse, err
+}
+
+// SetProductSortOrderContext positions products on the category's page.
+// Products in the category that are not listed keep their position.
+func (s *CategoriesService) SetProductSortOrderContext(ctx context.Context, categoryID int, sortOrders []ProductSortOrder) error {
+	path := fmt.Sprintf("catalog/categories/%d/products/sort-order", categoryID)
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, sortOrders)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
+
+// UploadImageContext replaces the category image with file contents rather
+// than a URL. The upload endpoint only echoes the image URL, so the category
+// is re-read to return it with the new CDN URL.
+func (s *CategoriesService) UploadImageContext(ctx context.Context, categoryID int, filename string, file io.Reader) (*CategoryResponse, error) {
+	path := fmt.Sprintf("catalog/categories/%d/image", categoryID)
+
+	req, err := s.client.newUploadRequest(ctx, path, nil, filename, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.client.Do(req, nil); err != nil {
+		return nil, err
+	}
+
+	return s.GetContext(ctx, categoryID, nil)
+}
+
+// DeleteManyContext deletes categories in chunks, returning the error for
+// each ID that could not be deleted. Subcategories removed by the API along
+// with their parent may still be listed: a filter naming an ID that no
+// longer exists matches nothing rather than failing.
+func (s *CategoriesService) DeleteManyContext(ctx context.Context, ids []int) map[int]error {
+	return s.client.deleteMany(ctx, "catalog/categories", ids)
+}
+
+func (s *CategoriesService) DeleteContext(ctx context.Context, id int) error {
+	return s.resource.delete(ctx, id)
+}
+
+type ChannelsService struct {
+	client *Client
+
+	// defaultID caches DefaultIDContext, which never changes for a store
+	mu        sync.Mutex
+	defaultID int
+}
+
+// DefaultIDContext returns the ID of the store's own storefront channel, the
+// one pricing and listings fall back to. The API has no default flag, so it
+// is the oldest BigCommerce storefront channel. The ID is looked up once and
+// cached on the client; failed lookups are retried on the next call.
+func (s *ChannelsService) DefaultIDContext(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.defaultID != 0 {
+		return s.defaultID, nil
+	}
+
+	channelsResponse, err := s.ListContext(ctx, &QueryParams{Limit: maxPageLimit})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list channels: %w", err)
+	}
+
+	for _, channel := range channelsResponse.Data {
+		if channel.Platform != "bigcommerce" || channel.Type != "storefront" {
+			continue
+		}
+		if s.defaultID == 0 || channel.ID < s.defaultID {
+			s.defaultID = channel.ID
+		}
+	}
+	if s.defaultID == 0 {
+		return 0, fmt.Errorf("default storefront channel: %w", ErrNotFound)
+	}
+
+	return s.defaultID, nil
+}
+
+func (s *ChannelsService) ListContext(ctx context.Context, params *QueryParams) (*ChannelsResponse, error) {
+	path := "channels"
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
+
+	channelsResponse := new(ChannelsResponse)
+	_, err = s.client.Do(req, channelsResponse)
+	return channelsResponse, err
+}
+
+func (s *ChannelsService) GetContext(ctx context.Context, channelID int) (*ChannelResponse, error) {
+	path := fmt.Sprintf("channels/%d", channelID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	channelResponse := new(ChannelResponse)
+	_, err = s.client.Do(req, channelResponse)
+	if err == nil && channelResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return channelResponse, err
+}
+
+func (s *ChannelsService) CreateContext(ctx context.Context, channel *Channel) (*ChannelResponse, error) {
+	path := "channels"
+
+	req, err := s.client.NewRequest(ctx, "POST", path, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	channelResponse := new(ChannelResponse)
+	_, err = s.client.Do(req, channelResponse)
+	return channelResponse, err
+}
+
+func (s *ChannelsService) UpdateContext(ctx context.Context, channelID int, channel *Channel) (*ChannelResponse, error) {
+	path := fmt.Sprintf("channels/%d", channelID)
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	channelResponse := new(ChannelResponse)
+	_, err = s.client.Do(req, channelResponse)
+	return channelResponse, err
+}
+
+func (s *ChannelsService) DeleteContext(ctx context.Context, channelID int) error {
+	path := fmt.Sprintf("channels/%d", channelID)
+
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
+
+type ComplexRulesService struct {
+	client *Client
+}
+
+func (s *ComplexRulesService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ComplexRulesResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/complex-rules", productID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
+
+	rulesResponse := new(ComplexRulesResponse)
+	_, err = s.client.Do(req, rulesResponse)
+	return rulesResponse, err
+}
+
+func (s *ComplexRulesService) GetContext(ctx context.Context, productID, ruleID int) (*ComplexRuleResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/complex-rules/%d", productID, ruleID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleResponse := new(ComplexRuleResponse)
+	_, err = s.client.Do(req, ruleResponse)
+	if err == nil && ruleResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return ruleResponse, err
+}
+
+func (s *ComplexRulesService) CreateContext(ctx context.Context, productID int, rule *ComplexRule) (*ComplexRuleResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/complex-rules", productID)
+
+	req, err := s.client.NewRequest(ctx, "POST", path, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleResponse := new(ComplexRuleResponse)
+	_, err = s.client.Do(req, ruleResponse)
+	return ruleResponse, err
+}
+
+func (s *ComplexRulesService) UpdateContext(ctx context.Context, productID, ruleID int, rule *ComplexRule) (*ComplexRuleResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/complex-rules/%d", productID, ruleID)
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleResponse := new(ComplexRuleResponse)
+	_, err = s.client.Do(req, ruleResponse)
+	return ruleResponse, err
+}
+
+// DeleteAllContext deletes every complex rule on the product.
+func (s *ComplexRulesService) DeleteAllContext(ctx context.Context, productID int) error {
+	return deleteAll(ctx, func(ctx context.Context, params *QueryParams) ([]ComplexRule, Meta, error) {
+		response, err := s.ListContext(ctx, productID, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, func(item ComplexRule) int { return item.ID }, func(ctx context.Context, id int) error {
+		return s.DeleteContext(ctx, productID, id)
+	})
+}
+
+func (s *ComplexRulesService) DeleteContext(ctx context.Context, productID, ruleID int) error {
+	path := fmt.Sprintf("catalog/products/%d/complex-rules/%d", productID, ruleID)
+
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
+
+type CustomFieldsService struct {
+	client *Client
+}
+
+func (s *CustomFieldsService) ListContext(ctx context.Context, productID int, params *QueryParams) (*CustomFieldsResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/custom-fields", productID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
+
+	fieldsResponse := new(CustomFieldsResponse)
+	_, err = s.client.Do(req, fieldsResponse)
+	return fieldsResponse, err
+}
+
+func (s *CustomFieldsService) GetContext(ctx context.Context, productID, fieldID int) (*CustomFieldResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/custom-fields/%d", productID, fieldID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldResponse := new(CustomFieldResponse)
+	_, err = s.client.Do(req, fieldResponse)
+	if err == nil && fieldResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return fieldResponse, err
+}
+
+func (s *CustomFieldsService) CreateContext(ctx context.Context, productID int, field *CustomField) (*CustomFieldResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/custom-fields", productID)
+
+	req, err := s.client.NewRequest(ctx, "POST", path, field)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldResponse := new(CustomFieldResponse)
+	_, err = s.client.Do(req, fieldResponse)
+	return fieldResponse, err
+}
+
+func (s *CustomFieldsService) UpdateContext(ctx context.Context, productID, fieldID int, field *CustomField) (*CustomFieldResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/custom-fields/%d", productID, fieldID)
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, field)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldResponse := new(CustomFieldResponse)
+	_, err = s.client.Do(req, fieldResponse)
+	return fieldResponse, err
+}
+
+// ReplaceAllContext makes fields the product's complete set of custom
+// fields, matched by name: existing fields are updated, missing ones are
+// created, and fields not in the set, including duplicates of a name, are
+// deleted. It returns the product's fields afterwards.
+func (s *CustomFieldsService) ReplaceAllContext(ctx context.Context, productID int, fields []CustomField) ([]CustomField, error) {
+	existing, err := listAll(ctx, nil, func(ctx context.Context, params *QueryParams) ([]CustomField, Meta, error) {
+		fieldsResponse, err := s.ListContext(ctx, productID, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return fieldsResponse.Data, fieldsResponse.Meta, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom fields: %w", err)
+	}
+
+	existingByName := make(map[string]CustomField, len(existing))
+	for _, field := range existing {
+		if _, ok := existingByName[field.Name]; ok {
+			// A duplicate name; only the first is kept
+			if err := s.DeleteContext(ctx, productID, field.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete duplicate custom field %q: %w", field.Name, err)
+			}
+			continue
+		}
+		existingByName[field.Name] = field
+	}
+
+	wanted := DedupeCustomFieldsByName(fields)
+	wantedNames := make(map[string]bool, len(wanted))
+	result := make([]CustomField, 0, len(wanted))
+
+	for _, field := range wanted {
+		wantedNames[field.Name] = true
+
+		current, ok := existingByName[field.Name]
+		if ok && current.Value == field.Value {
+			result = append(result, current)
+			continue
+		}
+
+		var fieldResponse *CustomFieldResponse
+		if ok {
+			fieldResponse, err = s.UpdateContext(ctx, productID, current.ID, &CustomField{Name: field.Name, Value: field.Value})
+		} else {
+			fieldResponse, err = s.CreateContext(ctx, productID, &CustomField{Name: field.Name, Value: field.Value})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to save custom field %q: %w", field.Name, err)
+		}
+		result = append(result, fieldResponse.Data)
+	}
+
+	for name, field := range existingByName {
+		if wantedNames[name] {
+			continue
+		}
+		if err := s.DeleteContext(ctx, productID, field.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete custom field %q: %w", name, err)
+		}
+	}
+
+	return result, nil
+}
+
+// DedupeCustomFieldsByName keeps the first field of each name, preserving
+// order.
+func DedupeCustomFieldsByName(fields []CustomField) []CustomField {
+	seen := make(map[string]bool, len(fields))
+	deduped := make([]CustomField, 0, len(fields))
+	for _, field := range fields {
+		if seen[field.Name] {
+			continue
+		}
+		seen[field.Name] = true
+		deduped = append(deduped, field)
+	}
+	return deduped
+}
+
+func (s *CustomFieldsService) DeleteContext(ctx context.Context, productID, fieldID int) error {
+	path := fmt.Sprintf("catalog/products/%d/custom-fields/%d", productID, fieldID)
+
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
+
+type ProductImagesService struct {
+	client *Client
+}
+
+func (s *ProductImagesService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ProductImagesResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/images", productID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
+
+	imagesResponse := new(ProductImagesResponse)
+	_, err = s.client.Do(req, imagesResponse)
+	return imagesResponse, err
+}
+
+func (s *ProductImagesService) GetContext(ctx context.Context, productID, imageID int) (*ProductImageResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/images/%d", productID, imageID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	imageResponse := new(ProductImageResponse)
+	_, err = s.client.Do(req, imageResponse)
+	if err == nil && imageResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return imageResponse, err
+}
+
+func (s *ProductImagesService) CreateContext(ctx context.Context, productID int, image *ProductImage) (*ProductImageResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/images", productID)
+
+	req, err := s.client.NewRequest(ctx, "POST", path, image)
+	if err != nil {
+		return nil, err
+	}
+
+	imageResponse := new(ProductImageResponse)
+	_, err = s.client.Do(req, imageResponse)
+	return imageResponse, err
+}
+
+// UploadContext creates a product image from file contents rather than a
+// URL. filename is only used to name the multipart part.
+func (s *ProductImagesService) UploadContext(ctx context.Context, productID int, filename string, file io.Reader, image *ProductImage) (*ProductImageResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/images", productID)
+
+	fields := map[string]string{
+		"is_thumbnail": strconv.FormatBool(image.IsThumbnail),
+		"sort_order":   strconv.Itoa(image.SortOrder),
+	}
+	if image.Description != "" {
+		fields["description"] = image.Description
+	}
+
+	req, err := s.client.newUploadRequest(ctx, path, fields, filename, file)
+	if err != nil {
+		return nil, err
+	}
+
+	imageResponse := new(ProductImageResponse)
+	_, err = s.client.Do(req, imageResponse)
+	return imageResponse, err
+}
+
+func (s *ProductImagesService) UpdateContext(ctx context.Context, productID, imageID int, image *ProductImage) (*ProductImageResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/images/%d", productID, imageID)
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, image)
+	if err != nil {
+		return nil, err
+	}
+
+	imageResponse := new(ProductImageResponse)
+	_, err = s.client.Do(req, imageResponse)
+	return imageResponse, err
+}
+
+// DeleteAllContext deletes every image on the product.
+func (s *ProductImagesService) DeleteAllContext(ctx context.Context, productID int) error {
+	return deleteAll(ctx, func(ctx context.Context, params *QueryParams) ([]ProductImage, Meta, error) {
+		response, err := s.ListContext(ctx, productID, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, func(item ProductImage) int { return item.ID }, func(ctx context.Context, id int) error {
+		return s.DeleteContext(ctx, productID, id)
+	})
+}
+
+func (s *ProductImagesService) DeleteContext(ctx context.Context, productID, imageID int) error {
+	path := fmt.Sprintf("catalog/products/%d/images/%d", productID, imageID)
+
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
+
+type MetafieldsService struct {
+	client *Client
+}
+
+func (s *MetafieldsService) ListContext(ctx context.Context, resourceType string, resourceID int, params *QueryParams) (*MetafieldsResponse, error) {
+	path := fmt.Sprintf("catalog/%s/%d/metafields", resourceType, resourceID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
+
+	metafieldsResponse := new(MetafieldsResponse)
+	_, err = s.client.Do(req, metafieldsResponse)
+	return metafieldsResponse, err
+}
+
+func (s *MetafieldsService) GetContext(ctx context.Context, resourceType string, resourceID, metafieldID int) (*MetafieldResponse, error) {
+	path := fmt.Sprintf("catalog/%s/%d/metafields/%d", resourceType, resourceID, metafieldID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	metafieldResponse := new(MetafieldResponse)
+	_, err = s.client.Do(req, metafieldResponse)
+	if err == nil && metafieldResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return metafieldResponse, err
+}
+
+func (s *MetafieldsService) CreateContext(ctx context.Context, resourceType string, resourceID int, metafield *Metafield) (*MetafieldResponse, error) {
+	path := fmt.Sprintf("catalog/%s/%d/metafields", resourceType, resourceID)
+
+	req, err := s.client.NewRequest(ctx, "POST", path, metafield)
+	if err != nil {
+		return nil, err
+	}
+
+	metafieldResponse := new(MetafieldResponse)
+	_, err = s.client.Do(req, metafieldResponse)
+	return metafieldResponse, err
+}
+
+// metafieldBatchSize is the most metafields the batch endpoint accepts in
+// one request.
+const metafieldBatchSize = 50
+
+// CreateBatchContext creates metafields on a single resource through the
+// batch endpoint, splitting them into requests of metafieldBatchSize. The
+// returned metafields carry their assigned IDs along with the namespace and
+// key that were sent.
+func (s *MetafieldsService) CreateBatchContext(ctx context.Context, resourceType string, resourceID int, metafields []Metafield) ([]Metafield, error) {
+	path := fmt.Sprintf("catalog/%s/metafields", resourceType)
+
+	created := make([]Metafield, 0, len(metafields))
+	for start := 0; start < len(metafields); start += metafieldBatchSize {
+		end := start + metafieldBatchSize
+		if end > len(metafields) {
+			end = len(metafields)
+		}
+
+		batch := make([]Metafield, end-start)
+		copy(batch, metafields[start:end])
+		for i := range batch {
+			batch[i].ResourceID = resourceID
+		}
+
+		req, err := s.client.NewRequest(ctx, "POST", path, batch)
+		if err != nil {
+			return created, err
+		}
+
+		metafieldsResponse := new(MetafieldsResponse)
+		if _, err := s.client.Do(req, metafieldsResponse); err != nil {
+			return created, err
+		}
+		created = append(created, metafieldsResponse.Data...)
+	}
+
+	return created, nil
+}
+
+func (s *MetafieldsService) UpdateContext(ctx context.Context, resourceType string, resourceID, metafieldID int, metafield *Metafield) (*MetafieldResponse, error) {
+	path := fmt.Sprintf("catalog/%s/%d/metafields/%d", resourceType, resourceID, metafieldID)
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, metafield)
+	if err != nil {
+		return nil, err
+	}
+
+	metafieldResponse := new(MetafieldResponse)
+	_, err = s.client.Do(req, metafieldResponse)
+	return metafieldResponse, err
+}
+
+func (s *MetafieldsService) DeleteContext(ctx context.Context, resourceType string, resourceID, metafieldID int) error {
+	path := fmt.Sprintf("catalog/%s/%d/metafields/%d", resourceType, resourceID, metafieldID)
+
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
+
+type ModifiersService struct {
+	client *Client
+}
+
+func (s *ModifiersService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ModifiersResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/modifiers", productID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
+
+	modifiersResponse := new(ModifiersResponse)
+	_, err = s.client.Do(req, modifiersResponse)
+	return modifiersResponse, err
+}
+
+func (s *ModifiersService) GetContext(ctx context.Context, productID, modifierID int) (*ModifierResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/modifiers/%d", productID, modifierID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	modifierResponse := new(ModifierResponse)
+	_, err = s.client.Do(req, modifierResponse)
+	if err == nil && modifierResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return modifierResponse, err
+}
+
+func (s *ModifiersService) CreateContext(ctx context.Context, productID int, modifier *Modifier) (*ModifierResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/modifiers", productID)
+
+	req, err := s.client.NewRequest(ctx, "POST", path, modifier)
+	if err != nil {
+		return nil, err
+	}
+
+	modifierResponse := new(ModifierResponse)
+	_, err = s.client.Do(req, modifierResponse)
+	return modifierResponse, err
+}
+
+func (s *ModifiersService) UpdateContext(ctx context.Context, productID, modifierID int, modifier *Modifier) (*ModifierResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/modifiers/%d", productID, modifierID)
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, modifier)
+	if err != nil {
+		return nil, err
+	}
+
+	modifierResponse := new(ModifierResponse)
+	_, err = s.client.Do(req, modifierResponse)
+	return modifierResponse, err
+}
+
+// DeleteAllContext deletes every modifier on the product, and with them
+// their values.
+func (s *ModifiersService) DeleteAllContext(ctx context.Context, productID int) error {
+	return deleteAll(ctx, func(ctx context.Context, params *QueryParams) ([]Modifier, Meta, error) {
+		response, err := s.ListContext(ctx, productID, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, func(item Modifier) int { return item.ID }, func(ctx context.Context, id int) error {
+		return s.DeleteContext(ctx, productID, id)
+	})
+}
+
+func (s *ModifiersService) DeleteContext(ctx context.Context, productID, modifierID int) error {
+	path := fmt.Sprintf("catalog/products/%d/modifiers/%d", productID, modifierID)
+
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
+
+func (s *ModifiersService) GetModifierValuesContext(ctx context.Context, productID, modifierID int, params *QueryParams) (*OptionValuesResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/modifiers/%d/values", productID, modifierID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
+
+	valuesResponse := new(OptionValuesResponse)
+	_, err = s.client.Do(req, valuesResponse)
+	return valuesResponse, err
+}
+
+func (s *ModifiersService) GetModifierValueContext(ctx context.Context, productID, modifierID, valueID int) (*OptionValueResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/modifiers/%d/values/%d", productID, modifierID, valueID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	valueResponse := new(OptionValueResponse)
+	_, err = s.client.Do(req, valueResponse)
+	if err == nil && valueResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return valueResponse, err
+}
+
+func (s *ModifiersService) CreateModifierValueContext(ctx context.Context, productID, modifierID int, value *OptionValue) (*OptionValueResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/modifiers/%d/values", productID, modifierID)
+
+	req, err := s.client.NewRequest(ctx, "POST", path, value)
+	if err != nil {
+		return nil, err
+	}
+
+	valueResponse := new(OptionValueResponse)
+	_, err = s.client.Do(req, valueResponse)
+	return valueResponse, err
+}
+
+// NormalizeModifierValues prepares values for a modifier of modifierType:
+// sort orders follow slice order, and a dropdown or radio modifier gets
+// exactly one default, the first value flagged or else the first value.
+// Checkbox, text, date and file modifiers take at most one value, as the
+// store supplies their choices itself, so more is an error.
+func NormalizeModifierValues(modifierType string, values []OptionValue) ([]OptionValue, error) {
+	switch modifierType {
+	case "checkbox", "text", "multi_line_text", "numbers_only_text", "date", "file":
+		if len(values) > 1 {
+			return nil, fmt.Errorf("%s modifier takes at most one value, got %d", modifierType, len(values))
+		}
+	}
+
+	normalized := make([]OptionValue, len(values))
+	copy(normalized, values)
+
+	defaultIndex := -1
+	for i := range normalized {
+		normalized[i].SortOrder = i
+		if normalized[i].IsDefault && defaultIndex < 0 {
+			defaultIndex = i
+		}
+		normalized[i].IsDefault = false
+	}
+
+	switch modifierType {
+	case "dropdown", "radio_buttons", "radio":
+		if len(normalized) > 0 && defaultIndex < 0 {
+			defaultIndex = 0
+		}
+	}
+	if defaultIndex >= 0 {
+		normalized[defaultIndex].IsDefault = true
+	}
+
+	return normalized, nil
+}
+
+// CreateModifierValuesContext adds values to a modifier in order after
+// NormalizeModifierValues, returning the values created with their IDs. On
+// error the values created before it are returned.
+func (s *ModifiersService) CreateModifierValuesContext(ctx context.Context, productID int, modifier *Modifier, values []OptionValue) ([]OptionValue, error) {
+	normalized, err := NormalizeModifierValues(modifier.Type, values)
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]OptionValue, 0, len(normalized))
+	for i := range normalized {
+		valueResponse, err := s.CreateModifierValueContext(ctx, productID, modifier.ID, &normalized[i])
+		if err != nil {
+			return created, fmt.Errorf("failed to create modifier value %q: %w", normalized[i].Label, err)
+		}
+		created = append(created, valueResponse.Data)
+	}
+
+	return created, nil
+}
+
+func (s *ModifiersService) UpdateModifierValueContext(ctx context.Context, productID, modifierID, valueID int, value *OptionValue) (*OptionValueResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/modifiers/%d/values/%d", productID, modifierID, valueID)
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, value)
+	if err != nil {
+		return nil, err
+	}
+
+	valueResponse := new(OptionValueResponse)
+	_, err = s.client.Do(req, valueResponse)
+	return valueResponse, err
+}
+
+func (s *ModifiersService) DeleteModifierValueContext(ctx context.Context, productID, modifierID, valueID int) error {
+	path := fmt.Sprintf("catalog/products/%d/modifiers/%d/values/%d", productID, modifierID, valueID)
+
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
+
+type OptionsService struct {
+	client *Client
+}
+
+func (s *OptionsService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ProductOptionsResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/options", productID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
+
+	optionsResponse := new(ProductOptionsResponse)
+	_, err = s.client.Do(req, optionsResponse)
+	return optionsResponse, err
+}
+
+func (s *OptionsService) GetContext(ctx context.Context, productID, optionID int) (*ProductOptionResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/options/%d", productID, optionID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	optionResponse := new(ProductOptionResponse)
+	_, err = s.client.Do(req, optionResponse)
+	if err == nil && optionResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return optionResponse, err
+}
+
+func (s *OptionsService) CreateContext(ctx context.Context, productID int, option *ProductOption) (*ProductOptionResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/options", productID)
+
+	req, err := s.client.NewRequest(ctx, "POST", path, option)
+	if err != nil {
+		return nil, err
+	}
+
+	optionResponse := new(ProductOptionResponse)
+	_, err = s.client.Do(req, optionResponse)
+	return optionResponse, err
+}
+
+func (s *OptionsService) UpdateContext(ctx context.Context, productID, optionID int, option *ProductOption) (*ProductOptionResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/options/%d", productID, optionID)
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, option)
+	if err != nil {
+		return nil, err
+	}
+
+	optionResponse := new(ProductOptionResponse)
+	_, err = s.client.Do(req, optionResponse)
+	return optionResponse, err
+}
+
+// DeleteAllContext deletes every variant option on the product. The API
+// deletes the variants built from an option along with it, leaving the
+// product with only its base variant, so there is no need to delete
+// variants first; variant data to keep must be read beforehand.
+func (s *OptionsService) DeleteAllContext(ctx context.Context, productID int) error {
+	return deleteAll(ctx, func(ctx context.Context, params *QueryParams) ([]ProductOption, Meta, error) {
+		response, err := s.ListContext(ctx, productID, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, func(item ProductOption) int { return item.ID }, func(ctx context.Context, id int) error {
+		return s.DeleteContext(ctx, productID, id)
+	})
+}
+
+func (s *OptionsService) DeleteContext(ctx context.Context, productID, optionID int) error {
+	path := fmt.Sprintf("catalog/products/%d/options/%d", productID, optionID)
+
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
+}
+
+func (s *OptionsService) GetOptionValuesContext(ctx context.Context, productID, optionID int, params *QueryParams) (*OptionValuesResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/options/%d/values", productID, optionID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
+
+	valuesResponse := new(OptionValuesResponse)
+	_, err = s.client.Do(req, valuesResponse)
+	return valuesResponse, err
+}
+
+func (s *OptionsService) GetOptionValueContext(ctx context.Context, productID, optionID, valueID int) (*OptionValueResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/options/%d/values/%d", productID, optionID, valueID)
+
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	valueResponse := new(OptionValueResponse)
+	_, err = s.client.Do(req, valueResponse)
+	if err == nil && valueResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return valueResponse, err
 }
 
-type Brand struct {
-	ID              int        `json:"id,omitempty"`
-	Name            string     `json:"name"`
-	PageTitle       string     `json:"page_title,omitempty"`
-	MetaKeywords    []string   `json:"meta_keywords,omitempty"`
-	MetaDescription string     `json:"meta_description,omitempty"`
-	ImageURL        string     `json:"image_url,omitempty"`
-	SearchKeywords  string     `json:"search_keywords,omitempty"`
-	CustomURL       *CustomURL `json:"custom_url,omitempty"`
+func (s *OptionsService) CreateOptionValueContext(ctx context.Context, productID, optionID int, value *OptionValue) (*OptionValueResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/options/%d/values", productID, optionID)
+
+	req, err := s.client.NewRequest(ctx, "POST", path, value)
+	if err != nil {
+		return nil, err
+	}
+
+	valueResponse := new(OptionValueResponse)
+	_, err = s.client.Do(req, valueResponse)
+	return valueResponse, err
 }
 
-type Review struct {
-	ID           int    `json:"id,omitempty"`
-	ProductID    int    `json:"product_id,omitempty"`
-	Title        string `json:"title"`
-	Text         string `json:"text"`
-	Status       string `json:"status,omitempty"`
-	Rating       int    `json:"rating"`
-	Email        string `json:"email,omitempty"`
-	Name         string `json:"name,omitempty"`
-	DateCreated  string `json:"date_created,omitempty"`
-	DateModified string `json:"date_modified,omitempty"`
+func (s *OptionsService) UpdateOptionValueContext(ctx context.Context, productID, optionID, valueID int, value *OptionValue) (*OptionValueResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/options/%d/values/%d", productID, optionID, valueID)
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, value)
+	if err != nil {
+		return nil, err
+	}
+
+	valueResponse := new(OptionValueResponse)
+	_, err = s.client.Do(req, valueResponse)
+	return valueResponse, err
 }
 
-type ComplexRule struct {
-	ID            int             `json:"id,omitempty"`
-	ProductID     int             `json:"product_id,omitempty"`
-	Enabled       bool            `json:"enabled"`
-	Stop          bool            `json:"stop"`
-	Purchasing    bool            `json:"purchasing_disabled"`
-	PurchasingMsg string          `json:"purchasing_disabled_message,omitempty"`
-	Adjusters     RuleAdjusters   `json:"price_adjuster,omitempty"`
-	Conditions    []RuleCondition `json:"conditions"`
-	SortOrder     int             `json:"sort_order,omitempty"`
+func (s *OptionsService) DeleteOptionValueContext(ctx context.Context, productID, optionID, valueID int) error {
+	path := fmt.Sprintf("catalog/products/%d/options/%d/values/%d", productID, optionID, valueID)
+
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(req, nil)
+	return err
 }
 
-type RuleAdjusters struct {
-	Type   string  `json:"adjuster"`
-	Amount float64 `json:"adjuster_value,omitempty"`
+type ProductsService struct {
+	client   *Client
+	resource crud[Product, ProductResponse, ProductsResponse]
+	listDefaults
 }
 
-type RuleCondition struct {
-	OptionID int    `json:"product_option_id"`
-	ValueID  int    `json:"product_option_value_id"`
-	Rule     string `json:"rule,omitempty"`
+func (s *ProductsService) ListContext(ctx context.Context, params *QueryParams) (*ProductsResponse, error) {
+	productsResponse, _, err := s.ListWithResponseContext(ctx, params)
+	return productsResponse, err
 }
 
-type Channel struct {
-	ID                  int    `json:"id,omitempty"`
-	Name                string `json:"name"`
-	Type                string `json:"type"`
-	Platform            string `json:"platform,omitempty"`
-	Status              string `json:"status"`
-	IsListable          bool   `json:"is_listable"`
-	IsVisible           bool   `json:"is_visible"`
-	ExternalID          string `json:"external_id,omitempty"`
-	IsExternallyManaged bool   `json:"is_externally_managed,omitempty"`
-	DateCreated         string `json:"date_created,omitempty"`
-	DateModified        string `json:"date_modified,omitempty"`
+func (s *ProductsService) ListWithResponseContext(ctx context.Context, params *QueryParams) (*ProductsResponse, *Response, error) {
+	return s.resource.list(ctx, withContextChannel(ctx, s.withDefaults(params)))
 }
 
-type Metafield struct {
-	ID           int    `json:"id,omitempty"`
-	Key          string `json:"key"`
-	Value        string `json:"value"`
-	ResourceType string `json:"resource_type"`
-	ResourceID   int    `json:"resource_id"`
-	Description  string `json:"description,omitempty"`
-	Namespace    string `json:"namespace"`
-	Permission   string `json:"permission,omitempty"`
-	DateCreated  string `json:"date_created,omitempty"`
-	DateModified string `json:"date_modified,omitempty"`
+func (s *ProductsService) ListAllContext(ctx context.Context, params *QueryParams) ([]Product, error) {
+	return listAll(ctx, s.withDefaults(params), func(ctx context.Context, params *QueryParams) ([]Product, Meta, error) {
+		productsResponse, err := s.ListContext(ctx, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return productsResponse.Data, productsResponse.Meta, nil
+	})
 }
 
-type Summary struct {
-	InventoryLevel   int     `json:"inventory_level"`
-	InventoryWarning int     `json:"inventory_warning_level"`
-	PrimaryCategory  int     `json:"primary_category_id"`
-	TotalSold        int     `json:"total_sold"`
-	PrimaryImage     *Image  `json:"primary_image,omitempty"`
-	Availability     string  `json:"availability"`
-	AvgRating        float64 `json:"rating_average"`
-	NumReviews       int     `json:"number_of_reviews"`
+// ListByCategoryContext lists products in categoryID. Any other filters in
+// params still apply; a category filter in params is replaced.
+func (s *ProductsService) ListByCategoryContext(ctx context.Context, categoryID int, params *QueryParams) (*ProductsResponse, error) {
+	return s.ListContext(ctx, withCategory(params, categoryID))
 }
 
-type Image struct {
-	ID           int    `json:"id,omitempty"`
-	ProductID    int    `json:"product_id,omitempty"`
-	URLThumbnail string `json:"url_thumbnail,omitempty"`
-	URLStandard  string `json:"url_standard,omitempty"`
+func (s *ProductsService) ListAllByCategoryContext(ctx context.Context, categoryID int, params *QueryParams) ([]Product, error) {
+	return s.ListAllContext(ctx, withCategory(params, categoryID))
 }
 
-type CategoryResponse struct {
-	Data Category `json:"data"`
-	Meta Meta     `json:"meta"`
+// ListByBrandContext lists products of brandID. Any other filters in params
+// still apply; a brand filter in params is replaced.
+func (s *ProductsService) ListByBrandContext(ctx context.Context, brandID int, params *QueryParams) (*ProductsResponse, error) {
+	return s.ListContext(ctx, withBrand(params, brandID))
 }
 
-type CategoriesResponse struct {
-	Data []Category `json:"data"`
-	Meta Meta       `json:"meta"`
+func (s *ProductsService) ListAllByBrandContext(ctx context.Context, brandID int, params *QueryParams) ([]Product, error) {
+	return s.ListAllContext(ctx, withBrand(params, brandID))
 }
 
-type BrandResponse struct {
-	Data Brand `json:"data"`
-	Meta Meta  `json:"meta"`
+// SearchContext lists products matching query the way storefront search
+// does: the API's keyword filter matches it against name, SKU and
+// description. Results keep the API's order unless params sets a sort. Any
+// other filters in params still apply; keywords in params are replaced.
+func (s *ProductsService) SearchContext(ctx context.Context, query string, params *QueryParams) (*ProductsResponse, error) {
+	scoped, err := withKeyword(params, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListContext(ctx, scoped)
 }
 
-type BrandsResponse struct {
-	Data []Brand `json:"data"`
-	Meta Meta    `json:"meta"`
+// SearchAllContext is SearchContext over every page of results.
+func (s *ProductsService) SearchAllContext(ctx context.Context, query string, params *QueryParams) ([]Product, error) {
+	scoped, err := withKeyword(params, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListAllContext(ctx, scoped)
+}
+
+func withKeyword(params *QueryParams, query string) (*QueryParams, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("search query is empty")
+	}
+
+	scoped := params.Clone()
+	if scoped == nil {
+		scoped = &QueryParams{}
+	}
+	scoped.Keywords = query
+	return scoped, nil
+}
+
+func withCategory(params *QueryParams, categoryID int) *QueryParams {
+	scoped := params.Clone()
+	if scoped == nil {
+		scoped = &QueryParams{}
+	}
+	scoped.CategoryID = []int{categoryID}
+	return scoped
+}
+
+func withBrand(params *QueryParams, brandID int) *QueryParams {
+	scoped := params.Clone()
+	if scoped == nil {
+		scoped = &QueryParams{}
+	}
+	scoped.BrandID = []int{brandID}
+	return scoped
+}
+
+// StockReportEntry is one product, or one variant of a variant-tracked
+// product, in a stock report. VariantID is zero for product-level tracking.
+type StockReportEntry struct {
+	ProductID        int    `json:"product_id"`
+	VariantID        int    `json:"variant_id,omitempty"`
+	SKU              string `json:"sku"`
+	InventoryLevel   int    `json:"inventory_level"`
+	InventoryWarning int    `json:"inventory_warning_level"`
+}
+
+// ListOutOfStockContext reports tracked products and variants with no
+// inventory left.
+func (s *ProductsService) ListOutOfStockContext(ctx context.Context) ([]StockReportEntry, error) {
+	return s.stockReport(ctx, func(level, warning int) bool {
+		return level <= 0
+	})
+}
+
+// ListLowStockContext reports tracked products and variants at or below
+// their inventory warning level, including those out of stock.
+func (s *ProductsService) ListLowStockContext(ctx context.Context) ([]StockReportEntry, error) {
+	return s.stockReport(ctx, func(level, warning int) bool {
+		return level <= 0 || level <= warning
+	})
+}
+
+// stockReport streams the catalog with variants included and keeps every
+// tracked entry for which match is true. Products tracked by variant are
+// judged per variant rather than by the aggregate level.
+func (s *ProductsService) stockReport(ctx context.Context, match func(level, warning int) bool) ([]StockReportEntry, error) {
+	var report []StockReportEntry
+
+	params := &QueryParams{Include: []string{"variants"}}
+	err := s.StreamContext(ctx, params, func(product Product) error {
+		switch product.InventoryTracking {
+		case InventoryTrackingProduct:
+			if match(product.InventoryLevel, product.InventoryWarning) {
+				report = append(report, StockReportEntry{
+					ProductID:        product.ID,
+					SKU:              product.SKU,
+					InventoryLevel:   product.InventoryLevel,
+					InventoryWarning: product.InventoryWarning,
+				})
+			}
+		case InventoryTrackingVariant:
+			for _, variant := range product.Variants {
+				if match(variant.InventoryLevel, variant.InventoryWarningLevel) {
+					report = append(report, StockReportEntry{
+						ProductID:        product.ID,
+						VariantID:        variant.ID,
+						SKU:              variant.SKU,
+						InventoryLevel:   variant.InventoryLevel,
+						InventoryWarning: variant.InventoryWarningLevel,
+					})
+				}
+			}
+		}
+		return nil
+	})
+
+	return report, err
 }
 
-type VariantResponse struct {
-	Data Variant `json:"data"`
-	Meta Meta    `json:"meta"`
+// StreamContext calls fn for each product across all pages, decoding one
+// product at a time so memory stays flat regardless of catalog size. An
+// error from fn stops the stream and is returned unchanged.
+func (s *ProductsService) StreamContext(ctx context.Context, params *QueryParams, fn func(Product) error) error {
+	pageParams := withContextChannel(ctx, s.withDefaults(params)).Clone()
+	if pageParams == nil {
+		pageParams = &QueryParams{}
+	}
+	if pageParams.Limit == 0 {
+		pageParams.Limit = maxPageLimit
+	}
+	if pageParams.Page == 0 {
+		pageParams.Page = 1
+	}
+
+	for {
+		req, err := s.client.NewRequest(ctx, "GET", "catalog/products", nil)
+		if err != nil {
+			return err
+		}
+		req.URL.RawQuery = pageParams.ToValues().Encode()
+
+		stream := &productStream{fn: fn}
+		if _, err := s.client.Do(req, stream); err != nil {
+			return budgetError(ctx, err)
+		}
+
+		if stream.count == 0 || stream.meta.Pagination.CurrentPage >= stream.meta.Pagination.TotalPages {
+			return nil
+		}
+		pageParams.Page = stream.meta.Pagination.CurrentPage + 1
+	}
 }
 
-type VariantsResponse struct {
-	Data []Variant `json:"data"`
-	Meta Meta      `json:"meta"`
+// productStream decodes a products list response, handing each element of
+// data to fn as soon as it is read.
+type productStream struct {
+	fn    func(Product) error
+	meta  Meta
+	count int
 }
 
-type OptionValueResponse struct {
-	Data OptionValue `json:"data"`
-	Meta Meta        `json:"meta"`
+func (p *productStream) decodeStream(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case "data":
+			if err := expectDelim(dec, '['); err != nil {
+				return err
+			}
+			for dec.More() {
+				var product Product
+				if err := dec.Decode(&product); err != nil {
+					return err
+				}
+				p.count++
+				if err := p.fn(product); err != nil {
+					return err
+				}
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return err
+			}
+		case "meta":
+			if err := dec.Decode(&p.meta); err != nil {
+				return err
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+
+	return expectDelim(dec, '}')
 }
 
-type OptionValuesResponse struct {
-	Data []OptionValue `json:"data"`
-	Meta Meta          `json:"meta"`
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != want {
+		return fmt.Errorf("unexpected token %v, want %v", token, want)
+	}
+	return nil
 }
 
-type ProductOptionResponse struct {
-	Data ProductOption `json:"data"`
-	Meta Meta          `json:"meta"`
+func (s *ProductsService) GetContext(ctx context.Context, id int, params *QueryParams) (*ProductResponse, error) {
+	productResponse, _, err := s.GetWithResponseContext(ctx, id, params)
+	return productResponse, err
 }
 
-type ProductOptionsResponse struct {
-	Data []ProductOption `json:"data"`
-	Meta Meta            `json:"meta"`
+func (s *ProductsService) GetWithResponseContext(ctx context.Context, id int, params *QueryParams) (*ProductResponse, *Response, error) {
+	productResponse, resp, err := s.resource.get(ctx, id, params)
+	if resp != nil {
+		productResponse.ETag = resp.ETag()
+	}
+	if err == nil && productResponse.Data.ID == 0 {
+		err = emptyGet(fmt.Sprintf("%s/%d", s.resource.path, id))
+	}
+	return productResponse, resp, err
 }
 
-type ModifierResponse struct {
-	Data Modifier `json:"data"`
-	Meta Meta     `json:"meta"`
+func (s *ProductsService) CreateContext(ctx context.Context, product *Product) (*ProductResponse, error) {
+	productResponse, _, err := s.CreateWithResponseContext(ctx, product)
+	return productResponse, err
 }
 
-type ModifiersResponse struct {
-	Data []Modifier `json:"data"`
-	Meta Meta       `json:"meta"`
+func (s *ProductsService) CreateWithResponseContext(ctx context.Context, product *Product) (*ProductResponse, *Response, error) {
+	productResponse, resp, err := s.resource.create(ctx, product.withoutReadOnly())
+	if resp != nil {
+		productResponse.ETag = resp.ETag()
+	}
+	return productResponse, resp, err
 }
 
-type ReviewResponse struct {
-	Data Review `json:"data"`
-	Meta Meta   `json:"meta"`
+// CreateDeepContext creates product together with its nested resources in a
+// single POST. The API accepts images, videos, custom_fields,
+// bulk_pricing_rules and variants inline, and options are derived from the
+// variants' option values. Modifiers, reviews and complex rules have their
+// own endpoints, so a product carrying any of them is rejected rather than
+// having them silently dropped. The returned product includes the IDs
+// assigned to the nested resources.
+func (s *ProductsService) CreateDeepContext(ctx context.Context, product *Product) (*ProductResponse, error) {
+	var unsupported []string
+	if len(product.Options) > 0 {
+		unsupported = append(unsupported, "options")
+	}
+	if len(product.Modifiers) > 0 {
+		unsupported = append(unsupported, "modifiers")
+	}
+	if len(product.Reviews) > 0 {
+		unsupported = append(unsupported, "reviews")
+	}
+	if len(product.ComplexRules) > 0 {
+		unsupported = append(unsupported, "complex_rules")
+	}
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("product %q: %s cannot be created inline", product.Name, strings.Join(unsupported, ", "))
+	}
+
+	path := "catalog/products"
+
+	req, err := s.client.NewRequest(ctx, "POST", path, product.withoutReadOnly())
+	if err != nil {
+		return nil, err
+	}
+
+	req.URL.RawQuery = (&QueryParams{Include: []string{"images", "videos", "custom_fields", "bulk_pricing_rules", "variants"}}).ToValues().Encode()
+
+	productResponse := new(ProductResponse)
+	_, err = s.client.Do(req, productResponse)
+	return productResponse, err
 }
 
-type ReviewsResponse struct {
-	Data []Review `json:"data"`
-	Meta Meta     `json:"meta"`
+// UpdateContext replaces the fields set on product. To guard against
+// overwriting a concurrent edit, read the product first and update with
+// WithIfMatch(ctx, response.ETag); a changed product then fails with an
+// error wrapping ErrPreconditionFailed.
+func (s *ProductsService) UpdateContext(ctx context.Context, id int, product *Product) (*ProductResponse, error) {
+	productResponse, _, err := s.UpdateWithResponseContext(ctx, id, product)
+	return productResponse, err
 }
 
-type ComplexRuleResponse struct {
-	Data ComplexRule `json:"data"`
-	Meta Meta        `json:"meta"`
+func (s *ProductsService) UpdateWithResponseContext(ctx context.Context, id int, product *Product) (*ProductResponse, *Response, error) {
+	productResponse, resp, err := s.resource.update(ctx, id, product.withoutReadOnly())
+	if resp != nil {
+		productResponse.ETag = resp.ETag()
+	}
+	return productResponse, resp, err
 }
 
-type ComplexRulesResponse struct {
-	Data []ComplexRule `json:"data"`
-	Meta Meta          `json:"meta"`
+func (s *ProductsService) GetBySKUContext(ctx context.Context, sku string) (*ProductResponse, error) {
+	productsResponse, err := s.ListContext(ctx, &QueryParams{SKU: sku})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, product := range productsResponse.Data {
+		if product.SKU == sku {
+			return &ProductResponse{Data: product, Meta: productsResponse.Meta}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("product with sku %q: %w", sku, ErrNotFound)
 }
 
-type CustomFieldResponse struct {
-	Data CustomField `json:"data"`
-	Meta Meta        `json:"meta"`
+// GetManyContext fetches the products with ids in as few requests as the
+// URL length allows, keyed by ID. IDs with no product are absent from the
+// map rather than an error. Filters in params still apply, so a product
+// they exclude is absent too; an id filter in params is replaced.
+func (s *ProductsService) GetManyContext(ctx context.Context, ids []int, params *QueryParams) (map[int]Product, error) {
+	products := make(map[int]Product, len(ids))
+	for start := 0; start < len(ids); start += idInChunkSize {
+		end := start + idInChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		chunkParams := params.Clone()
+		if chunkParams == nil {
+			chunkParams = &QueryParams{}
+		}
+		chunkParams.IDIn = ids[start:end]
+
+		chunk, err := s.ListAllContext(ctx, chunkParams)
+		for _, product := range chunk {
+			products[product.ID] = product
+		}
+		if err != nil {
+			return products, err
+		}
+	}
+
+	return products, nil
 }
 
-type CustomFieldsResponse struct {
-	Data []CustomField `json:"data"`
-	Meta Meta          `json:"meta"`
+// errStopStream ends a StreamContext scan early without reporting an error.
+var errStopStream = errors.New("stop stream")
+
+// GetByURLContext returns the product whose custom URL is path. The catalog
+// API cannot filter by URL, so products are streamed until one matches.
+// path may be a full storefront URL or a slug with or without its slashes.
+func (s *ProductsService) GetByURLContext(ctx context.Context, path string) (*ProductResponse, error) {
+	want, err := normalizeURLPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Product
+	err = s.StreamContext(ctx, nil, func(product Product) error {
+		if product.CustomURL == nil {
+			return nil
+		}
+		if got, err := normalizeURLPath(product.CustomURL.URL); err == nil && got == want {
+			found = &product
+			return errStopStream
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopStream) {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("product with url %q: %w", want, ErrNotFound)
+	}
+
+	return &ProductResponse{Data: *found}, nil
 }
 
-type PricingRuleResponse struct {
-	Data PricingRule `json:"data"`
-	Meta Meta        `json:"meta"`
-}
+// normalizeURLPath reduces a storefront URL to the slash-wrapped path form
+// BigCommerce stores custom URLs in, e.g. "/blue-shirt/".
+func normalizeURLPath(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("invalid url %q: %w", raw, err)
+	}
 
-type PricingRulesResponse struct {
-	Data []PricingRule `json:"data"`
-	Meta Meta          `json:"meta"`
-}
+	path := strings.Trim(u.Path, "/")
+	if path == "" {
+		return "", fmt.Errorf("url %q has no path", raw)
+	}
 
-type ChannelResponse struct {
-	Data Channel `json:"data"`
-	Meta Meta    `json:"meta"`
+	return "/" + path + "/", nil
 }
 
-type ChannelsResponse struct {
-	Data []Channel `json:"data"`
-	Meta Meta      `json:"meta"`
+// UpsertBySKUContext updates the product matching product.SKU or creates it
+// when none exists. The boolean reports whether a new product was created.
+func (s *ProductsService) UpsertBySKUContext(ctx context.Context, product *Product) (*ProductResponse, bool, error) {
+	if product.SKU == "" {
+		return nil, false, errors.New("upsert requires a sku")
+	}
+
+	existing, err := s.GetBySKUContext(ctx, product.SKU)
+	if errors.Is(err, ErrNotFound) {
+		created, err := s.CreateContext(ctx, product)
+		return created, err == nil, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	update := *product
+	update.ID = 0
+
+	updated, err := s.UpdateContext(ctx, existing.Data.ID, &update)
+	return updated, false, err
 }
 
-type MetafieldResponse struct {
-	Data Metafield `json:"data"`
-	Meta Meta      `json:"meta"`
+func (s *ProductsService) DeleteContext(ctx context.Context, id int) error {
+	return s.resource.delete(ctx, id)
 }
 
-type MetafieldsResponse struct {
-	Data []Metafield `json:"data"`
-	Meta Meta        `json:"meta"`
+// DeleteResult splits the IDs a bulk delete matched into those deleted and
+// those that were not, with the error for each.
+type DeleteResult struct {
+	Deleted []int
+	Failed  map[int]error
 }
 
-type SummaryResponse struct {
-	Data Summary `json:"data"`
-	Meta Meta    `json:"meta"`
+// DeleteByFilterContext deletes every product matching params, listing them
+// all first and then deleting them in batches of deleteIDChunkSize. params
+// must filter, see QueryParams.HasFilter, so a missing filter cannot empty
+// the catalog. Only the caller's params count: the service's defaults and a
+// context channel still narrow the listing, but alone would delete a whole
+// channel. A batch that fails outright fails each of its IDs.
+func (s *ProductsService) DeleteByFilterContext(ctx context.Context, params *QueryParams) (*DeleteResult, error) {
+	if !params.HasFilter() {
+		return nil, errors.New("refusing to delete products without a filter")
+	}
+
+	products, err := s.ListAllContext(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products to delete: %w", err)
+	}
+
+	result := &DeleteResult{Failed: make(map[int]error)}
+	for start := 0; start < len(products); start += deleteIDChunkSize {
+		end := start + deleteIDChunkSize
+		if end > len(products) {
+			end = len(products)
+		}
+
+		ids := make([]int, 0, end-start)
+		for _, product := range products[start:end] {
+			ids = append(ids, product.ID)
+		}
+
+		batchResponse, err := s.client.Batch.DeleteProductsContext(ctx, ids)
+		if err != nil {
+			for _, id := range ids {
+				result.Failed[id] = err
+			}
+			continue
+		}
+		for _, batchError := range batchResponse.Data {
+			if batchError.ResourceID != 0 {
+				result.Failed[batchError.ResourceID] = errors.New(batchError.Error)
+			}
+		}
+		for _, id := range ids {
+			if _, failed := result.Failed[id]; !failed {
+				result.Deleted = append(result.Deleted, id)
+			}
+		}
+	}
+
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("%d of %d product deletes failed", len(result.Failed), len(products))
+	}
+	return result, nil
 }
 
-type BrandsService struct {
+type ReviewsService struct {
 	client *Client
 }
 
-func (s *BrandsService) ListContext(ctx context.Context, params *QueryParams) (*BrandsResponse, error) {
-	path := "catalog/brands"
+func (s *ReviewsService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ReviewsResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/reviews", productID)
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -836,56 +4258,69 @@ func (s *BrandsService) ListContext(ctx context.Context, params *QueryParams) (*
 		req.URL.RawQuery = params.ToValues().Encode()
 	}
 
-	brandsResponse := new(BrandsResponse)
-	_, err = s.client.Do(req, brandsResponse)
-	return brandsResponse, err
+	reviewsResponse := new(ReviewsResponse)
+	_, err = s.client.Do(req, reviewsResponse)
+	return reviewsResponse, err
 }
 
-func (s *BrandsService) GetContext(ctx context.Context, id int, params *QueryParams) (*BrandResponse, error) {
-	path := fmt.Sprintf("catalog/brands/%d", id)
+func (s *ReviewsService) GetContext(ctx context.Context, productID, reviewID int) (*ReviewResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/reviews/%d", productID, reviewID)
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if params != nil {
-		req.URL.RawQuery = params.ToValues().Encode()
+	reviewResponse := new(ReviewResponse)
+	_, err = s.client.Do(req, reviewResponse)
+	if err == nil && reviewResponse.Data.ID == 0 {
+		err = emptyGet(path)
 	}
-
-	brandResponse := new(BrandResponse)
-	_, err = s.client.Do(req, brandResponse)
-	return brandResponse, err
+	return reviewResponse, err
 }
 
-func (s *BrandsService) CreateContext(ctx context.Context, brand *Brand) (*BrandResponse, error) {
-	path := "catalog/brands"
+func (s *ReviewsService) CreateContext(ctx context.Context, productID int, review *Review) (*ReviewResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/reviews", productID)
 
-	req, err := s.client.NewRequest(ctx, "POST", path, brand)
+	req, err := s.client.NewRequest(ctx, "POST", path, review)
 	if err != nil {
 		return nil, err
 	}
 
-	brandResponse := new(BrandResponse)
-	_, err = s.client.Do(req, brandResponse)
-	return brandResponse, err
+	reviewResponse := new(ReviewResponse)
+	_, err = s.client.Do(req, reviewResponse)
+	return reviewResponse, err
 }
 
-func (s *BrandsService) UpdateContext(ctx context.Context, id int, brand *Brand) (*BrandResponse, error) {
-	path := fmt.Sprintf("catalog/brands/%d", id)
+func (s *ReviewsService) UpdateContext(ctx context.Context, productID, reviewID int, review *Review) (*ReviewResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/reviews/%d", productID, reviewID)
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, brand)
+	req, err := s.client.NewRequest(ctx, "PUT", path, review)
 	if err != nil {
 		return nil, err
 	}
 
-	brandResponse := new(BrandResponse)
-	_, err = s.client.Do(req, brandResponse)
-	return brandResponse, err
+	reviewResponse := new(ReviewResponse)
+	_, err = s.client.Do(req, reviewResponse)
+	return reviewResponse, err
 }
 
-func (s *BrandsService) DeleteContext(ctx context.Context, id int) error {
-	path := fmt.Sprintf("catalog/brands/%d", id)
+// DeleteAllContext deletes every review of the product, whatever its
+// status.
+func (s *ReviewsService) DeleteAllContext(ctx context.Context, productID int) error {
+	return deleteAll(ctx, func(ctx context.Context, params *QueryParams) ([]Review, Meta, error) {
+		response, err := s.ListContext(ctx, productID, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, func(item Review) int { return item.ID }, func(ctx context.Context, id int) error {
+		return s.DeleteContext(ctx, productID, id)
+	})
+}
+
+func (s *ReviewsService) DeleteContext(ctx context.Context, productID, reviewID int) error {
+	path := fmt.Sprintf("catalog/products/%d/reviews/%d", productID, reviewID)
 
 	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
@@ -896,29 +4331,139 @@ func (s *BrandsService) DeleteContext(ctx context.Context, id int) error {
 	return err
 }
 
-type CategoriesService struct {
+const ReviewStatusApproved = "approved"
+
+// AggregateRatings summarizes approved reviews the way the storefront does:
+// the mean rating, how many reviews counted, and how many gave each star
+// rating. Pending and disapproved reviews are ignored; use
+// AggregateAllRatings to include them. avg is zero when no review counts.
+func AggregateRatings(reviews []Review) (avg float64, count int, histogram map[int]int) {
+	return aggregateRatings(reviews, false)
+}
+
+// AggregateAllRatings is AggregateRatings over every review regardless of
+// status.
+func AggregateAllRatings(reviews []Review) (avg float64, count int, histogram map[int]int) {
+	return aggregateRatings(reviews, true)
+}
+
+func aggregateRatings(reviews []Review, includeAll bool) (float64, int, map[int]int) {
+	histogram := make(map[int]int)
+	total, count := 0, 0
+	for _, review := range reviews {
+		if !includeAll && review.Status != ReviewStatusApproved {
+			continue
+		}
+		histogram[review.Rating]++
+		total += review.Rating
+		count++
+	}
+
+	if count == 0 {
+		return 0, 0, histogram
+	}
+	return float64(total) / float64(count), count, histogram
+}
+
+type SummaryService struct {
 	client *Client
+
+	// cache holds the summaries GetManyContext fetched, by product ID
+	mu    sync.Mutex
+	cache map[int]cachedSummary
 }
 
-func (s *CategoriesService) ListContext(ctx context.Context, params *QueryParams) (*CategoriesResponse, error) {
-	path := "catalog/categories"
+type cachedSummary struct {
+	summary Summary
+	expires time.Time
+}
+
+const (
+	// summaryCacheTTL is how long GetManyContext reuses a summary, long
+	// enough for one render of a product grid.
+	summaryCacheTTL = 30 * time.Second
+
+	// summaryConcurrency bounds how many summaries GetManyContext fetches
+	// at once. A product grid asks for a whole page of summaries together,
+	// which on a cold cache would otherwise go out as one burst.
+	summaryConcurrency = 4
+)
+
+func (s *SummaryService) GetContext(ctx context.Context, productID int) (*SummaryResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/summary", productID)
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if params != nil {
-		req.URL.RawQuery = params.ToValues().Encode()
+	summaryResponse := new(SummaryResponse)
+	_, err = s.client.Do(req, summaryResponse)
+	return summaryResponse, err
+}
+
+// GetManyContext returns the summaries of productIDs, fetching those not
+// fetched by an earlier call within summaryCacheTTL, and the error for each
+// product whose summary could not be fetched. GetContext is never cached.
+func (s *SummaryService) GetManyContext(ctx context.Context, productIDs []int) (map[int]Summary, map[int]error) {
+	summaries := make(map[int]Summary, len(productIDs))
+	var missing []int
+	queued := make(map[int]bool)
+
+	s.mu.Lock()
+	now := time.Now()
+	for _, productID := range productIDs {
+		if _, ok := summaries[productID]; ok || queued[productID] {
+			continue
+		}
+		if cached, ok := s.cache[productID]; ok && now.Before(cached.expires) {
+			summaries[productID] = cached.summary
+			continue
+		}
+		missing = append(missing, productID)
+		queued[productID] = true
 	}
+	s.mu.Unlock()
 
-	categoriesResponse := new(CategoriesResponse)
-	_, err = s.client.Do(req, categoriesResponse)
-	return categoriesResponse, err
+	var mu sync.Mutex
+	errs := ForEach(ctx, missing, summaryConcurrency, func(ctx context.Context, productID int) error {
+		summaryResponse, err := s.GetContext(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		summaries[productID] = summaryResponse.Data
+		mu.Unlock()
+		return nil
+	})
+
+	s.mu.Lock()
+	if s.cache == nil {
+		s.cache = make(map[int]cachedSummary)
+	}
+	now = time.Now()
+	for productID, cached := range s.cache {
+		if !now.Before(cached.expires) {
+			delete(s.cache, productID)
+		}
+	}
+	for _, productID := range missing {
+		if summary, ok := summaries[productID]; ok {
+			s.cache[productID] = cachedSummary{summary: summary, expires: now.Add(summaryCacheTTL)}
+		}
+	}
+	s.mu.Unlock()
+
+	return summaries, errs
 }
 
-func (s *CategoriesService) GetContext(ctx context.Context, id int, params *QueryParams) (*CategoryResponse, error) {
-	path := fmt.Sprintf("catalog/categories/%d", id)
+type VariantsService struct {
+	client *Client
+}
+
+func (s *VariantsService) ListContext(ctx context.Context, productID int, params *QueryParams) (*VariantsResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/variants", productID)
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -929,41 +4474,49 @@ func (s *CategoriesService) GetContext(ctx context.Context, id int, params *Quer
 		req.URL.RawQuery = params.ToValues().Encode()
 	}
 
-	categoryResponse := new(CategoryResponse)
-	_, err = s.client.Do(req, categoryResponse)
-	return categoryResponse, err
+	variantsResponse := new(VariantsResponse)
+	_, err = s.client.Do(req, variantsResponse)
+	return variantsResponse, err
 }
 
-func (s *CategoriesService) CreateContext(ctx context.Context, category *Category) (*CategoryResponse, error) {
-	path := "catalog/categories"
+func (s *VariantsService) GetContext(ctx context.Context, productID, variantID int) (*VariantResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/variants/%d", productID, variantID)
 
-	req, err := s.client.NewRequest(ctx, "POST", path, category)
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	categoryResponse := new(CategoryResponse)
-	_, err = s.client.Do(req, categoryResponse)
-	return categoryResponse, err
+	variantResponse := new(VariantResponse)
+	_, err = s.client.Do(req, variantResponse)
+	if err == nil && variantResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return variantResponse, err
 }
 
-func (s *CategoriesService) UpdateContext(ctx context.Context, id int, category *Category) (*CategoryResponse, error) {
-	path := fmt.Sprintf("catalog/categories/%d", id)
+func (s *VariantsService) CreateContext(ctx context.Context, productID int, variant *Variant) (*VariantResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/variants", productID)
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, category)
+	req, err := s.client.NewRequest(ctx, "POST", path, variant)
 	if err != nil {
 		return nil, err
 	}
 
-	categoryResponse := new(CategoryResponse)
-	_, err = s.client.Do(req, categoryResponse)
-	return categoryResponse, err
+	variantResponse := new(VariantResponse)
+	_, err = s.client.Do(req, variantResponse)
+	return variantResponse, err
 }
 
-func (s *CategoriesService) DeleteContext(ctx context.Context, id int) error {
-	path := fmt.Sprintf("catalog/categories/%d", id)
+// CreateImageContext sets the image shown when variantID is selected.
+func (s *VariantsService) CreateImageContext(ctx context.Context, productID, variantID int, imageURL string) error {
+	path := fmt.Sprintf("catalog/products/%d/variants/%d/image", productID, variantID)
 
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	type variantImageRequest struct {
+		ImageURL string `json:"image_url"`
+	}
+
+	req, err := s.client.NewRequest(ctx, "POST", path, variantImageRequest{ImageURL: imageURL})
 	if err != nil {
 		return err
 	}
@@ -972,84 +4525,222 @@ func (s *CategoriesService) DeleteContext(ctx context.Context, id int) error {
 	return err
 }
 
-type ChannelsService struct {
-	client *Client
+// variantBatchConcurrency bounds how many variant creates CreateBatchContext
+// has in flight.
+const variantBatchConcurrency = 4
+
+// VariantBatchError lists the variants of a batch that failed, keyed by
+// their index in the request.
+type VariantBatchError struct {
+	Errors map[int]error
 }
 
-func (s *ChannelsService) ListContext(ctx context.Context, params *QueryParams) (*ChannelsResponse, error) {
-	path := "channels"
+func (e *VariantBatchError) Error() string {
+	indexes := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	msgs := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		msgs = append(msgs, fmt.Sprintf("variant %d: %v", i, e.Errors[i]))
+	}
+	return fmt.Sprintf("%d of the variants failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (e *VariantBatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// CreateBatchContext creates variants on a product. The catalog API's batch
+// variant endpoint only updates existing variants, so creates are issued
+// concurrently instead. The result is parallel to variants, with IDs set on
+// those that were created; failures, such as an invalid option value
+// combination, are reported in a *VariantBatchError.
+func (s *VariantsService) CreateBatchContext(ctx context.Context, productID int, variants []Variant) ([]Variant, error) {
+	created := make([]Variant, len(variants))
+	copy(created, variants)
+
+	indexes := make([]int, len(variants))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	errs := ForEach(ctx, indexes, variantBatchConcurrency, func(ctx context.Context, i int) error {
+		variantResponse, err := s.CreateContext(ctx, productID, &variants[i])
+		if err != nil {
+			return err
+		}
+		created[i] = variantResponse.Data
+		return nil
+	})
+	if len(errs) > 0 {
+		return created, &VariantBatchError{Errors: errs}
+	}
+
+	return created, nil
+}
+
+// ValidateContext checks variants against the product's current options
+// before they are created, since the API rejects a stale or misplaced
+// option value with an opaque error. It costs one request for the options;
+// callers already holding them can use ValidateVariantOptionValues. Invalid
+// variants are reported in a *VariantBatchError.
+func (s *VariantsService) ValidateContext(ctx context.Context, productID int, variants []Variant) error {
+	optionsResponse, err := s.client.Options.ListContext(ctx, productID, &QueryParams{Limit: maxPageLimit})
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to list options of product %d: %w", productID, err)
 	}
 
-	if params != nil {
-		req.URL.RawQuery = params.ToValues().Encode()
+	return ValidateVariantOptionValues(optionsResponse.Data, variants)
+}
+
+// ValidateVariantOptionValues checks that each variant picks exactly one
+// existing value of every option, naming the option or value at fault.
+// Invalid variants are reported in a *VariantBatchError keyed by index.
+func ValidateVariantOptionValues(options []ProductOption, variants []Variant) error {
+	byID := make(map[int]*ProductOption, len(options))
+	for i := range options {
+		byID[options[i].ID] = &options[i]
 	}
 
-	channelsResponse := new(ChannelsResponse)
-	_, err = s.client.Do(req, channelsResponse)
-	return channelsResponse, err
+	errs := make(map[int]error)
+	for i, variant := range variants {
+		var problems []string
+		seen := make(map[int]bool, len(variant.OptionValues))
+
+		for _, value := range variant.OptionValues {
+			option, ok := byID[value.OptionID]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("option %d does not exist on the product", value.OptionID))
+				continue
+			}
+			if seen[option.ID] {
+				problems = append(problems, fmt.Sprintf("option %q is given more than once", option.DisplayName))
+				continue
+			}
+			seen[option.ID] = true
+
+			if !hasOptionValue(option, value.ID) {
+				problems = append(problems, fmt.Sprintf("value %d (%s) does not belong to option %q", value.ID, value.Label, option.DisplayName))
+			}
+		}
+
+		for _, option := range options {
+			if !seen[option.ID] {
+				problems = append(problems, fmt.Sprintf("option %q has no value", option.DisplayName))
+			}
+		}
+
+		if len(problems) > 0 {
+			errs[i] = errors.New(strings.Join(problems, ", "))
+		}
+	}
+
+	if len(errs) > 0 {
+		return &VariantBatchError{Errors: errs}
+	}
+
+	return nil
 }
 
-func (s *ChannelsService) GetContext(ctx context.Context, channelID int) (*ChannelResponse, error) {
-	path := fmt.Sprintf("channels/%d", channelID)
+func hasOptionValue(option *ProductOption, valueID int) bool {
+	for _, value := range option.OptionValues {
+		if value.ID == valueID {
+			return true
+		}
+	}
+	return false
+}
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+func (s *VariantsService) UpdateContext(ctx context.Context, productID, variantID int, variant *Variant) (*VariantResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/variants/%d", productID, variantID)
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, variant)
 	if err != nil {
 		return nil, err
 	}
 
-	channelResponse := new(ChannelResponse)
-	_, err = s.client.Do(req, channelResponse)
-	return channelResponse, err
+	variantResponse := new(VariantResponse)
+	_, err = s.client.Do(req, variantResponse)
+	return variantResponse, err
 }
 
-func (s *ChannelsService) CreateContext(ctx context.Context, channel *Channel) (*ChannelResponse, error) {
-	path := "channels"
+func (s *VariantsService) DeleteContext(ctx context.Context, productID, variantID int) error {
+	path := fmt.Sprintf("catalog/products/%d/variants/%d", productID, variantID)
 
-	req, err := s.client.NewRequest(ctx, "POST", path, channel)
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	channelResponse := new(ChannelResponse)
-	_, err = s.client.Do(req, channelResponse)
-	return channelResponse, err
+	_, err = s.client.Do(req, nil)
+	return err
 }
 
-func (s *ChannelsService) UpdateContext(ctx context.Context, channelID int, channel *Channel) (*ChannelResponse, error) {
-	path := fmt.Sprintf("channels/%d", channelID)
+var ErrNoVariants = errors.New("product has no variants")
+
+// GetDefaultContext returns the product's base variant, falling back to the
+// variant whose option values are all the options' defaults.
+func (s *VariantsService) GetDefaultContext(ctx context.Context, productID int) (*VariantResponse, error) {
+	productResponse, err := s.client.Products.GetContext(ctx, productID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseVariantID := productResponse.Data.BaseVariantID; baseVariantID > 0 {
+		return s.GetContext(ctx, productID, baseVariantID)
+	}
+
+	variantsResponse, err := s.ListContext(ctx, productID, &QueryParams{Limit: 250})
+	if err != nil {
+		return nil, err
+	}
+	if len(variantsResponse.Data) == 0 {
+		return nil, fmt.Errorf("product %d: %w", productID, ErrNoVariants)
+	}
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, channel)
+	optionsResponse, err := s.client.Options.ListContext(ctx, productID, &QueryParams{Limit: 250})
 	if err != nil {
 		return nil, err
 	}
 
-	channelResponse := new(ChannelResponse)
-	_, err = s.client.Do(req, channelResponse)
-	return channelResponse, err
-}
-
-func (s *ChannelsService) DeleteContext(ctx context.Context, channelID int) error {
-	path := fmt.Sprintf("channels/%d", channelID)
+	defaults := make(map[int]bool)
+	for _, option := range optionsResponse.Data {
+		for _, value := range option.OptionValues {
+			if value.IsDefault {
+				defaults[value.ID] = true
+			}
+		}
+	}
 
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
-	if err != nil {
-		return err
+	for _, variant := range variantsResponse.Data {
+		isDefault := len(variant.OptionValues) > 0
+		for _, value := range variant.OptionValues {
+			if !defaults[value.ID] {
+				isDefault = false
+				break
+			}
+		}
+		if isDefault {
+			return &VariantResponse{Data: variant, Meta: variantsResponse.Meta}, nil
+		}
 	}
 
-	_, err = s.client.Do(req, nil)
-	return err
+	return nil, fmt.Errorf("product %d has no variant matching the default option values", productID)
 }
 
-type ComplexRulesService struct {
+type VideosService struct {
 	client *Client
 }
 
-func (s *ComplexRulesService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ComplexRulesResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/complex-rules", productID)
+func (s *VideosService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ProductVideosResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/videos", productID)
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -1060,52 +4751,68 @@ func (s *ComplexRulesService) ListContext(ctx context.Context, productID int, pa
 		req.URL.RawQuery = params.ToValues().Encode()
 	}
 
-	rulesResponse := new(ComplexRulesResponse)
-	_, err = s.client.Do(req, rulesResponse)
-	return rulesResponse, err
+	videosResponse := new(ProductVideosResponse)
+	_, err = s.client.Do(req, videosResponse)
+	return videosResponse, err
 }
 
-func (s *ComplexRulesService) GetContext(ctx context.Context, productID, ruleID int) (*ComplexRuleResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/complex-rules/%d", productID, ruleID)
+func (s *VideosService) GetContext(ctx context.Context, productID, videoID int) (*ProductVideoResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/videos/%d", productID, videoID)
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	ruleResponse := new(ComplexRuleResponse)
-	_, err = s.client.Do(req, ruleResponse)
-	return ruleResponse, err
+	videoResponse := new(ProductVideoResponse)
+	_, err = s.client.Do(req, videoResponse)
+	if err == nil && videoResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return videoResponse, err
 }
 
-func (s *ComplexRulesService) CreateContext(ctx context.Context, productID int, rule *ComplexRule) (*ComplexRuleResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/complex-rules", productID)
+func (s *VideosService) CreateContext(ctx context.Context, productID int, video *ProductVideo) (*ProductVideoResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/videos", productID)
 
-	req, err := s.client.NewRequest(ctx, "POST", path, rule)
+	req, err := s.client.NewRequest(ctx, "POST", path, video)
 	if err != nil {
 		return nil, err
 	}
 
-	ruleResponse := new(ComplexRuleResponse)
-	_, err = s.client.Do(req, ruleResponse)
-	return ruleResponse, err
+	videoResponse := new(ProductVideoResponse)
+	_, err = s.client.Do(req, videoResponse)
+	return videoResponse, err
 }
 
-func (s *ComplexRulesService) UpdateContext(ctx context.Context, productID, ruleID int, rule *ComplexRule) (*ComplexRuleResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/complex-rules/%d", productID, ruleID)
+func (s *VideosService) UpdateContext(ctx context.Context, productID, videoID int, video *ProductVideo) (*ProductVideoResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/videos/%d", productID, videoID)
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, rule)
+	req, err := s.client.NewRequest(ctx, "PUT", path, video)
 	if err != nil {
 		return nil, err
 	}
 
-	ruleResponse := new(ComplexRuleResponse)
-	_, err = s.client.Do(req, ruleResponse)
-	return ruleResponse, err
+	videoResponse := new(ProductVideoResponse)
+	_, err = s.client.Do(req, videoResponse)
+	return videoResponse, err
 }
 
-func (s *ComplexRulesService) DeleteContext(ctx context.Context, productID, ruleID int) error {
-	path := fmt.Sprintf("catalog/products/%d/complex-rules/%d", productID, ruleID)
+// DeleteAllContext deletes every video on the product.
+func (s *VideosService) DeleteAllContext(ctx context.Context, productID int) error {
+	return deleteAll(ctx, func(ctx context.Context, params *QueryParams) ([]ProductVideo, Meta, error) {
+		response, err := s.ListContext(ctx, productID, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, func(item ProductVideo) int { return item.ID }, func(ctx context.Context, id int) error {
+		return s.DeleteContext(ctx, productID, id)
+	})
+}
+
+func (s *VideosService) DeleteContext(ctx context.Context, productID, videoID int) error {
+	path := fmt.Sprintf("catalog/products/%d/videos/%d", productID, videoID)
 
 	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
@@ -1116,368 +4823,624 @@ func (s *ComplexRulesService) DeleteContext(ctx context.Context, productID, rule
 	return err
 }
 
-type CustomFieldsService struct {
+type ProductChannelAssignment struct {
+	ProductID int `json:"product_id"`
+	ChannelID int `json:"channel_id"`
+}
+
+// ChannelAssignment is a product's channel assignment with the channel's
+// details. Channel holds only the ID when the channel no longer exists.
+type ChannelAssignment struct {
+	ProductChannelAssignment
+	Channel Channel
+}
+
+type ProductChannelAssignmentsResponse struct {
+	Data []ProductChannelAssignment `json:"data"`
+	Meta Meta                       `json:"meta"`
+}
+
+type CategoryAssignment struct {
+	ProductID  int `json:"product_id"`
+	CategoryID int `json:"category_id"`
+}
+
+type CategoryAssignmentsResponse struct {
+	Data []CategoryAssignment `json:"data"`
+	Meta Meta                 `json:"meta"`
+}
+
+type BatchErrorResponse struct {
+	Data []BatchError `json:"data"`
+	Meta Meta         `json:"meta"`
+}
+
+type BatchError struct {
+	Error       string `json:"error"`
+	ResourceID  int    `json:"resource_id,omitempty"`
+	ResourceURL string `json:"resource_url,omitempty"`
+	Status      int    `json:"status"`
+}
+
+type BatchProductCreateRequest struct {
+	Products []Product `json:"products"`
+}
+
+type BatchProductUpdateRequest struct {
+	Products []Product `json:"products"`
+}
+
+type BatchProductsResponse struct {
+	Data []Product `json:"data"`
+	Meta Meta      `json:"meta"`
+}
+
+type PricingRequest struct {
+	ProductIDs   []int                      `json:"product_ids,omitempty"`
+	VariantIDs   []int                      `json:"variant_ids,omitempty"`
+	IncludeTaxes bool                       `json:"include_taxes,omitempty"`
+	Currencies   []string                   `json:"currencies,omitempty"`
+	Context      PricingRequestContext      `json:"context,omitempty"`
+	Filters      PricingRequestFilters      `json:"filters,omitempty"`
+	Aggregations PricingRequestAggregations `json:"aggregations,omitempty"`
+}
+
+type PricingRequestContext struct {
+	ChannelID       int `json:"channel_id,omitempty"`
+	CustomerID      int `json:"customer_id,omitempty"`
+	CustomerGroupID int `json:"customer_group_id,omitempty"`
+}
+
+type PricingRequestFilters struct {
+	SalesTaxable bool `json:"sales_taxable,omitempty"`
+}
+
+type PricingRequestAggregations struct {
+	TaxExcludedPriceMin bool `json:"tax_excluded_price_min,omitempty"`
+	TaxExcludedPriceMax bool `json:"tax_excluded_price_max,omitempty"`
+	TaxIncludedPriceMin bool `json:"tax_included_price_min,omitempty"`
+	TaxIncludedPriceMax bool `json:"tax_included_price_max,omitempty"`
+}
+
+type PricingResponse struct {
+	Data PricingData `json:"data"`
+	Meta Meta        `json:"meta"`
+}
+
+type PricingData struct {
+	Products     map[string]PricingProductData `json:"products,omitempty"`
+	Variants     map[string]PricingVariantData `json:"variants,omitempty"`
+	Aggregations PricingAggregationData        `json:"aggregations,omitempty"`
+}
+
+type PricingProductData struct {
+	PriceExcludingTax       float64       `json:"price_excluding_tax"`
+	PriceIncludingTax       float64       `json:"price_including_tax"`
+	TaxAmount               float64       `json:"tax_amount"`
+	RetailPriceExcludingTax float64       `json:"retail_price_excluding_tax"`
+	RetailPriceIncludingTax float64       `json:"retail_price_including_tax"`
+	RetailTaxAmount         float64       `json:"retail_tax_amount"`
+	SalePriceExcludingTax   float64       `json:"sale_price_excluding_tax"`
+	SalePriceIncludingTax   float64       `json:"sale_price_including_tax"`
+	SaleTaxAmount           float64       `json:"sale_tax_amount"`
+	MapPriceExcludingTax    float64       `json:"map_price_excluding_tax"`
+	MapPriceIncludingTax    float64       `json:"map_price_including_tax"`
+	MapTaxAmount            float64       `json:"map_tax_amount"`
+	BulkPricingTiers        []PricingTier `json:"bulk_pricing_tiers"`
+	Currency                string        `json:"currency"`
+}
+
+type PricingVariantData struct {
+	PriceExcludingTax       float64       `json:"price_excluding_tax"`
+	PriceIncludingTax       float64       `json:"price_including_tax"`
+	TaxAmount               float64       `json:"tax_amount"`
+	RetailPriceExcludingTax float64       `json:"retail_price_excluding_tax"`
+	RetailPriceIncludingTax float64       `json:"retail_price_including_tax"`
+	RetailTaxAmount         float64       `json:"retail_tax_amount"`
+	SalePriceExcludingTax   float64       `json:"sale_price_excluding_tax"`
+	SalePriceIncludingTax   float64       `json:"sale_price_including_tax"`
+	SaleTaxAmount           float64       `json:"sale_tax_amount"`
+	MapPriceExcludingTax    float64       `json:"map_price_excluding_tax"`
+	MapPriceIncludingTax    float64       `json:"map_price_including_tax"`
+	MapTaxAmount            float64       `json:"map_tax_amount"`
+	BulkPricingTiers        []PricingTier `json:"bulk_pricing_tiers"`
+	Currency                string        `json:"currency"`
+}
+
+type PricingTier struct {
+	QuantityMin       int     `json:"quantity_min"`
+	QuantityMax       int     `json:"quantity_max,omitempty"`
+	Type              string  `json:"type"`
+	Amount            float64 `json:"amount"`
+	PriceExcludingTax float64 `json:"price_excluding_tax"`
+	PriceIncludingTax float64 `json:"price_including_tax"`
+	TaxAmount         float64 `json:"tax_amount"`
+}
+
+type PricingAggregationData struct {
+	TaxExcludedPriceMin float64 `json:"tax_excluded_price_min,omitempty"`
+	TaxExcludedPriceMax float64 `json:"tax_excluded_price_max,omitempty"`
+	TaxIncludedPriceMin float64 `json:"tax_included_price_min,omitempty"`
+	TaxIncludedPriceMax float64 `json:"tax_included_price_max,omitempty"`
+}
+
+type ProductAggregatedInventory struct {
+	ProductID         int    `json:"product_id"`
+	InventoryLevel    int    `json:"inventory_level"`
+	InventoryWarning  int    `json:"inventory_warning_level"`
+	WarrantiesCount   int    `json:"warranties_count"`
+	VariantsCount     int    `json:"variants_count"`
+	InventoryTracking string `json:"inventory_tracking"`
+}
+
+type ProductInventoryResponse struct {
+	Data ProductAggregatedInventory `json:"data"`
+	Meta Meta                       `json:"meta"`
+}
+
+type ProductInventoriesResponse struct {
+	Data []ProductAggregatedInventory `json:"data"`
+	Meta Meta                         `json:"meta"`
+}
+
+type RelatedProductsService struct {
 	client *Client
 }
 
-func (s *CustomFieldsService) ListContext(ctx context.Context, productID int, params *QueryParams) (*CustomFieldsResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/custom-fields", productID)
+type ProductChannelAssignmentsService struct {
+	client *Client
+}
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
+type ProductCategoriesService struct {
+	client *Client
+}
 
-	if params != nil {
-		req.URL.RawQuery = params.ToValues().Encode()
-	}
+type BatchService struct {
+	client *Client
+}
 
-	fieldsResponse := new(CustomFieldsResponse)
-	_, err = s.client.Do(req, fieldsResponse)
-	return fieldsResponse, err
+type PricingService struct {
+	client *Client
+}
+
+type InventoryService struct {
+	client *Client
 }
 
-func (s *CustomFieldsService) GetContext(ctx context.Context, productID, fieldID int) (*CustomFieldResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/custom-fields/%d", productID, fieldID)
+func (s *RelatedProductsService) CreateContext(ctx context.Context, productID int, relatedProductIDs []int) (*http.Response, error) {
+	path := fmt.Sprintf("catalog/products/%d/related", productID)
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	type RelatedProductsRequest struct {
+		ProductIDs []int `json:"product_ids"`
+	}
+
+	req, err := s.client.NewRequest(ctx, "POST", path, RelatedProductsRequest{ProductIDs: relatedProductIDs})
 	if err != nil {
 		return nil, err
 	}
 
-	fieldResponse := new(CustomFieldResponse)
-	_, err = s.client.Do(req, fieldResponse)
-	return fieldResponse, err
+	return s.client.Do(req, nil)
 }
 
-func (s *CustomFieldsService) CreateContext(ctx context.Context, productID int, field *CustomField) (*CustomFieldResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/custom-fields", productID)
+// AssignContext relates relatedProductIDs to the product like CreateContext,
+// but reports which IDs were assigned and why the others were not.
+func (s *RelatedProductsService) AssignContext(ctx context.Context, productID int, relatedProductIDs []int) (*AssignmentResult, error) {
+	return assignEach(ctx, relatedProductIDs, func(ctx context.Context, ids []int) error {
+		_, err := s.CreateContext(ctx, productID, ids)
+		return err
+	})
+}
 
-	req, err := s.client.NewRequest(ctx, "POST", path, field)
+func (s *RelatedProductsService) DeleteContext(ctx context.Context, productID int) (*http.Response, error) {
+	path := fmt.Sprintf("catalog/products/%d/related", productID)
+
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	fieldResponse := new(CustomFieldResponse)
-	_, err = s.client.Do(req, fieldResponse)
-	return fieldResponse, err
+	return s.client.Do(req, nil)
 }
 
-func (s *CustomFieldsService) UpdateContext(ctx context.Context, productID, fieldID int, field *CustomField) (*CustomFieldResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/custom-fields/%d", productID, fieldID)
+func (s *RelatedProductsService) DeleteByIDContext(ctx context.Context, productID, relatedProductID int) (*http.Response, error) {
+	path := fmt.Sprintf("catalog/products/%d/related/%d", productID, relatedProductID)
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, field)
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	fieldResponse := new(CustomFieldResponse)
-	_, err = s.client.Do(req, fieldResponse)
-	return fieldResponse, err
+	return s.client.Do(req, nil)
 }
 
-func (s *CustomFieldsService) DeleteContext(ctx context.Context, productID, fieldID int) error {
-	path := fmt.Sprintf("catalog/products/%d/custom-fields/%d", productID, fieldID)
+func (s *ProductChannelAssignmentsService) ListContext(ctx context.Context, productID int) (*ProductChannelAssignmentsResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/channels", productID)
 
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = s.client.Do(req, nil)
-	return err
-}
-
-type ProductImagesService struct {
-	client *Client
+	assignmentsResponse := new(ProductChannelAssignmentsResponse)
+	_, err = s.client.Do(req, assignmentsResponse)
+	return assignmentsResponse, err
 }
 
-func (s *ProductImagesService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ProductImagesResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/images", productID)
-
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+// ListWithChannelsContext returns the product's channel assignments joined
+// with their channels, which are fetched together in one id:in listing
+// rather than one request per assignment.
+func (s *ProductChannelAssignmentsService) ListWithChannelsContext(ctx context.Context, productID int) ([]ChannelAssignment, error) {
+	assignmentsResponse, err := s.ListContext(ctx, productID)
 	if err != nil {
 		return nil, err
 	}
+	if len(assignmentsResponse.Data) == 0 {
+		return nil, nil
+	}
 
-	if params != nil {
-		req.URL.RawQuery = params.ToValues().Encode()
+	channelIDs := make([]int, 0, len(assignmentsResponse.Data))
+	seen := make(map[int]bool, len(assignmentsResponse.Data))
+	for _, assignment := range assignmentsResponse.Data {
+		if !seen[assignment.ChannelID] {
+			seen[assignment.ChannelID] = true
+			channelIDs = append(channelIDs, assignment.ChannelID)
+		}
 	}
 
-	imagesResponse := new(ProductImagesResponse)
-	_, err = s.client.Do(req, imagesResponse)
-	return imagesResponse, err
-}
+	channels, err := listAll(ctx, &QueryParams{IDIn: channelIDs}, func(ctx context.Context, params *QueryParams) ([]Channel, Meta, error) {
+		channelsResponse, err := s.client.Channels.ListContext(ctx, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return channelsResponse.Data, channelsResponse.Meta, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channels for product %d: %w", productID, err)
+	}
 
-func (s *ProductImagesService) GetContext(ctx context.Context, productID, imageID int) (*ProductImageResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/images/%d", productID, imageID)
+	byID := make(map[int]Channel, len(channels))
+	for _, channel := range channels {
+		byID[channel.ID] = channel
+	}
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
+	assignments := make([]ChannelAssignment, len(assignmentsResponse.Data))
+	for i, assignment := range assignmentsResponse.Data {
+		channel, ok := byID[assignment.ChannelID]
+		if !ok {
+			channel = Channel{ID: assignment.ChannelID}
+		}
+		assignments[i] = ChannelAssignment{ProductChannelAssignment: assignment, Channel: channel}
 	}
 
-	imageResponse := new(ProductImageResponse)
-	_, err = s.client.Do(req, imageResponse)
-	return imageResponse, err
+	return assignments, nil
 }
 
-func (s *ProductImagesService) CreateContext(ctx context.Context, productID int, image *ProductImage) (*ProductImageResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/images", productID)
+func (s *ProductChannelAssignmentsService) CreateContext(ctx context.Context, productID int, channelIDs []int) (*http.Response, error) {
+	path := fmt.Sprintf("catalog/products/%d/channels", productID)
 
-	req, err := s.client.NewRequest(ctx, "POST", path, image)
+	type ChannelAssignmentsRequest struct {
+		ChannelIDs []int `json:"channel_ids"`
+	}
+
+	req, err := s.client.NewRequest(ctx, "POST", path, ChannelAssignmentsRequest{ChannelIDs: channelIDs})
 	if err != nil {
 		return nil, err
 	}
 
-	imageResponse := new(ProductImageResponse)
-	_, err = s.client.Do(req, imageResponse)
-	return imageResponse, err
+	return s.client.Do(req, nil)
 }
 
-func (s *ProductImagesService) UpdateContext(ctx context.Context, productID, imageID int, image *ProductImage) (*ProductImageResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/images/%d", productID, imageID)
+func (s *ProductChannelAssignmentsService) DeleteChannelContext(ctx context.Context, productID, channelID int) (*http.Response, error) {
+	path := fmt.Sprintf("catalog/products/%d/channels/%d", productID, channelID)
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, image)
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	imageResponse := new(ProductImageResponse)
-	_, err = s.client.Do(req, imageResponse)
-	return imageResponse, err
+	return s.client.Do(req, nil)
 }
 
-func (s *ProductImagesService) DeleteContext(ctx context.Context, productID, imageID int) error {
-	path := fmt.Sprintf("catalog/products/%d/images/%d", productID, imageID)
+func (s *ProductCategoriesService) ListContext(ctx context.Context, productID int) (*CategoryAssignmentsResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/categories", productID)
 
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = s.client.Do(req, nil)
-	return err
+	assignmentsResponse := new(CategoryAssignmentsResponse)
+	_, err = s.client.Do(req, assignmentsResponse)
+	return assignmentsResponse, err
 }
 
-type MetafieldsService struct {
-	client *Client
-}
+func (s *ProductCategoriesService) CreateContext(ctx context.Context, productID int, categoryIDs []int) (*http.Response, error) {
+	path := fmt.Sprintf("catalog/products/%d/categories", productID)
 
-func (s *MetafieldsService) ListContext(ctx context.Context, resourceType string, resourceID int, params *QueryParams) (*MetafieldsResponse, error) {
-	path := fmt.Sprintf("catalog/%s/%d/metafields", resourceType, resourceID)
+	type CategoryAssignmentsRequest struct {
+		CategoryIDs []int `json:"category_ids"`
+	}
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	req, err := s.client.NewRequest(ctx, "POST", path, CategoryAssignmentsRequest{CategoryIDs: categoryIDs})
 	if err != nil {
 		return nil, err
 	}
 
-	if params != nil {
-		req.URL.RawQuery = params.ToValues().Encode()
-	}
+	return s.client.Do(req, nil)
+}
 
-	metafieldsResponse := new(MetafieldsResponse)
-	_, err = s.client.Do(req, metafieldsResponse)
-	return metafieldsResponse, err
+// AssignContext adds the product to categoryIDs like CreateContext, but
+// reports which IDs were assigned and why the others were not.
+func (s *ProductCategoriesService) AssignContext(ctx context.Context, productID int, categoryIDs []int) (*AssignmentResult, error) {
+	return assignEach(ctx, categoryIDs, func(ctx context.Context, ids []int) error {
+		_, err := s.CreateContext(ctx, productID, ids)
+		return err
+	})
 }
 
-func (s *MetafieldsService) GetContext(ctx context.Context, resourceType string, resourceID, metafieldID int) (*MetafieldResponse, error) {
-	path := fmt.Sprintf("catalog/%s/%d/metafields/%d", resourceType, resourceID, metafieldID)
+// categoryAssignConcurrency bounds how many products AssignManyContext
+// assigns at once, leaving the rate limit for other work.
+const categoryAssignConcurrency = 4
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
+// AssignManyContext adds every product in productIDs to categoryID, keeping
+// the categories each is already in, and returns the error for each product
+// that could not be assigned.
+func (s *ProductCategoriesService) AssignManyContext(ctx context.Context, productIDs []int, categoryID int) map[int]error {
+	return ForEach(ctx, productIDs, categoryAssignConcurrency, func(ctx context.Context, productID int) error {
+		_, err := s.CreateContext(ctx, productID, []int{categoryID})
+		return err
+	})
+}
 
-	metafieldResponse := new(MetafieldResponse)
-	_, err = s.client.Do(req, metafieldResponse)
-	return metafieldResponse, err
+// AssignmentResult splits the IDs of an assignment request into those now
+// assigned and those rejected, with the error for each.
+type AssignmentResult struct {
+	Assigned []int
+	Failed   map[int]error
 }
 
-func (s *MetafieldsService) CreateContext(ctx context.Context, resourceType string, resourceID int, metafield *Metafield) (*MetafieldResponse, error) {
-	path := fmt.Sprintf("catalog/%s/%d/metafields", resourceType, resourceID)
+// assignEach sends ids in one request and, when the API rejects it as a
+// client error, retries them one at a time since the rejection does not say
+// which IDs were at fault. Server errors, rate limiting and cancellation
+// fail every ID still unassigned.
+func assignEach(ctx context.Context, ids []int, assign func(context.Context, []int) error) (*AssignmentResult, error) {
+	result := &AssignmentResult{Failed: make(map[int]error)}
 
-	req, err := s.client.NewRequest(ctx, "POST", path, metafield)
-	if err != nil {
-		return nil, err
+	err := assign(ctx, ids)
+	if err == nil {
+		result.Assigned = append(result.Assigned, ids...)
+		return result, nil
 	}
 
-	metafieldResponse := new(MetafieldResponse)
-	_, err = s.client.Do(req, metafieldResponse)
-	return metafieldResponse, err
-}
-
-func (s *MetafieldsService) UpdateContext(ctx context.Context, resourceType string, resourceID, metafieldID int, metafield *Metafield) (*MetafieldResponse, error) {
-	path := fmt.Sprintf("catalog/%s/%d/metafields/%d", resourceType, resourceID, metafieldID)
+	var errorResponse *ErrorResponse
+	rejected := errors.As(err, &errorResponse) && errorResponse.Response != nil &&
+		errorResponse.Response.StatusCode < 500 && !errors.Is(err, ErrRateLimited)
+	if !rejected || len(ids) == 1 {
+		for _, id := range ids {
+			result.Failed[id] = err
+		}
+		return result, fmt.Errorf("%d of %d assignments failed: %w", len(ids), len(ids), err)
+	}
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, metafield)
-	if err != nil {
-		return nil, err
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			result.Failed[id] = err
+			continue
+		}
+		if err := assign(ctx, []int{id}); err != nil {
+			result.Failed[id] = err
+			continue
+		}
+		result.Assigned = append(result.Assigned, id)
 	}
 
-	metafieldResponse := new(MetafieldResponse)
-	_, err = s.client.Do(req, metafieldResponse)
-	return metafieldResponse, err
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("%d of %d assignments failed", len(result.Failed), len(ids))
+	}
+	return result, nil
 }
 
-func (s *MetafieldsService) DeleteContext(ctx context.Context, resourceType string, resourceID, metafieldID int) error {
-	path := fmt.Sprintf("catalog/%s/%d/metafields/%d", resourceType, resourceID, metafieldID)
+func (s *ProductCategoriesService) DeleteContext(ctx context.Context, productID int) (*http.Response, error) {
+	path := fmt.Sprintf("catalog/products/%d/categories", productID)
 
 	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = s.client.Do(req, nil)
-	return err
-}
-
-type ModifiersService struct {
-	client *Client
+	return s.client.Do(req, nil)
 }
 
-func (s *ModifiersService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ModifiersResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/modifiers", productID)
+func (s *ProductCategoriesService) DeleteCategoryContext(ctx context.Context, productID, categoryID int) (*http.Response, error) {
+	path := fmt.Sprintf("catalog/products/%d/categories/%d", productID, categoryID)
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if params != nil {
-		req.URL.RawQuery = params.ToValues().Encode()
-	}
-
-	modifiersResponse := new(ModifiersResponse)
-	_, err = s.client.Do(req, modifiersResponse)
-	return modifiersResponse, err
+	return s.client.Do(req, nil)
 }
 
-func (s *ModifiersService) GetContext(ctx context.Context, productID, modifierID int) (*ModifierResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/modifiers/%d", productID, modifierID)
+func (s *BatchService) CreateProductsContext(ctx context.Context, products []Product) (*BatchProductsResponse, error) {
+	path := "catalog/products"
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	req, err := s.client.NewRequest(ctx, "POST", path, BatchProductCreateRequest{Products: productsWithoutReadOnly(products)})
 	if err != nil {
 		return nil, err
 	}
 
-	modifierResponse := new(ModifierResponse)
-	_, err = s.client.Do(req, modifierResponse)
-	return modifierResponse, err
+	batchResponse := new(BatchProductsResponse)
+	_, err = s.client.Do(req, batchResponse)
+	return batchResponse, err
 }
 
-func (s *ModifiersService) CreateContext(ctx context.Context, productID int, modifier *Modifier) (*ModifierResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/modifiers", productID)
+func (s *BatchService) UpdateProductsContext(ctx context.Context, products []Product) (*BatchProductsResponse, error) {
+	path := "catalog/products"
 
-	req, err := s.client.NewRequest(ctx, "POST", path, modifier)
+	req, err := s.client.NewRequest(ctx, "PUT", path, BatchProductUpdateRequest{Products: productsWithoutReadOnly(products)})
 	if err != nil {
 		return nil, err
 	}
 
-	modifierResponse := new(ModifierResponse)
-	_, err = s.client.Do(req, modifierResponse)
-	return modifierResponse, err
+	batchResponse := new(BatchProductsResponse)
+	_, err = s.client.Do(req, batchResponse)
+	return batchResponse, err
 }
 
-func (s *ModifiersService) UpdateContext(ctx context.Context, productID, modifierID int, modifier *Modifier) (*ModifierResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/modifiers/%d", productID, modifierID)
+// productBatchSize is the most products the batch update endpoint accepts
+// in one request.
+const productBatchSize = 10
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, modifier)
-	if err != nil {
-		return nil, err
+// PatchProductsContext updates only the fields present in each map, so
+// unlike UpdateProductsContext zero values are sent rather than dropped and
+// unnamed fields are left alone. Every map must include the product "id".
+// Updates are sent in chunks of productBatchSize; on error the response
+// holds the products updated by the chunks that succeeded.
+func (s *BatchService) PatchProductsContext(ctx context.Context, updates []map[string]interface{}) (*BatchProductsResponse, error) {
+	path := "catalog/products"
+
+	for i, update := range updates {
+		if _, ok := update["id"]; !ok {
+			return nil, fmt.Errorf("product update %d has no id", i)
+		}
 	}
 
-	modifierResponse := new(ModifierResponse)
-	_, err = s.client.Do(req, modifierResponse)
-	return modifierResponse, err
-}
+	type batchProductPatchRequest struct {
+		Products []map[string]interface{} `json:"products"`
+	}
 
-func (s *ModifiersService) DeleteContext(ctx context.Context, productID, modifierID int) error {
-	path := fmt.Sprintf("catalog/products/%d/modifiers/%d", productID, modifierID)
+	batchResponse := new(BatchProductsResponse)
+	for start := 0; start < len(updates); start += productBatchSize {
+		end := start + productBatchSize
+		if end > len(updates) {
+			end = len(updates)
+		}
 
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
-	if err != nil {
-		return err
+		req, err := s.client.NewRequest(ctx, "PUT", path, batchProductPatchRequest{Products: updates[start:end]})
+		if err != nil {
+			return batchResponse, err
+		}
+
+		chunkResponse := new(BatchProductsResponse)
+		if _, err := s.client.Do(req, chunkResponse); err != nil {
+			return batchResponse, budgetError(ctx, fmt.Errorf("failed to update products %d-%d: %w", start, end-1, err))
+		}
+		batchResponse.Data = append(batchResponse.Data, chunkResponse.Data...)
+		batchResponse.Meta = chunkResponse.Meta
 	}
 
-	_, err = s.client.Do(req, nil)
-	return err
+	return batchResponse, nil
 }
 
-func (s *ModifiersService) GetModifierValuesContext(ctx context.Context, productID, modifierID int, params *QueryParams) (*OptionValuesResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/modifiers/%d/values", productID, modifierID)
+// DeleteProductsContext deletes productIDs with one DELETE filtered by
+// id:in, the only way the endpoint takes IDs, so callers keep each call to
+// deleteIDChunkSize IDs. No IDs deletes nothing rather than sending a
+// DELETE without a filter.
+func (s *BatchService) DeleteProductsContext(ctx context.Context, productIDs []int) (*BatchErrorResponse, error) {
+	if len(productIDs) == 0 {
+		return new(BatchErrorResponse), nil
+	}
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	path := "catalog/products"
+
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return nil, err
 	}
+	req.URL.RawQuery = (&QueryParams{IDIn: productIDs}).ToValues().Encode()
 
-	if params != nil {
-		req.URL.RawQuery = params.ToValues().Encode()
-	}
-
-	valuesResponse := new(OptionValuesResponse)
-	_, err = s.client.Do(req, valuesResponse)
-	return valuesResponse, err
+	batchResponse := new(BatchErrorResponse)
+	_, err = s.client.Do(req, batchResponse)
+	return batchResponse, err
 }
 
-func (s *ModifiersService) GetModifierValueContext(ctx context.Context, productID, modifierID, valueID int) (*OptionValueResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/modifiers/%d/values/%d", productID, modifierID, valueID)
+func (s *PricingService) GetContext(ctx context.Context, request PricingRequest) (*PricingResponse, error) {
+	path := "pricing/products"
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	// The API treats a zero channel ambiguously, so always name one
+	if request.Context.ChannelID == 0 {
+		channelID, ok := ChannelIDFromContext(ctx)
+		if !ok {
+			var err error
+			if channelID, err = s.client.Channels.DefaultIDContext(ctx); err != nil {
+				return nil, err
+			}
+		}
+		request.Context.ChannelID = channelID
+	}
+
+	req, err := s.client.NewRequest(ctx, "POST", path, request)
 	if err != nil {
 		return nil, err
 	}
 
-	valueResponse := new(OptionValueResponse)
-	_, err = s.client.Do(req, valueResponse)
-	return valueResponse, err
+	pricingResponse := new(PricingResponse)
+	_, err = s.client.Do(req, pricingResponse)
+	return pricingResponse, err
 }
 
-func (s *ModifiersService) CreateModifierValueContext(ctx context.Context, productID, modifierID int, value *OptionValue) (*OptionValueResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/modifiers/%d/values", productID, modifierID)
+func (s *InventoryService) GetContext(ctx context.Context, productID int) (*ProductInventoryResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/inventory", productID)
 
-	req, err := s.client.NewRequest(ctx, "POST", path, value)
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	valueResponse := new(OptionValueResponse)
-	_, err = s.client.Do(req, valueResponse)
-	return valueResponse, err
+	inventoryResponse := new(ProductInventoryResponse)
+	_, err = s.client.Do(req, inventoryResponse)
+	return inventoryResponse, err
 }
 
-func (s *ModifiersService) UpdateModifierValueContext(ctx context.Context, productID, modifierID, valueID int, value *OptionValue) (*OptionValueResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/modifiers/%d/values/%d", productID, modifierID, valueID)
+// ListContext returns the aggregated inventory for productIDs, splitting the
+// IDs into chunks and following pagination within each chunk. No IDs lists
+// the inventory of every product.
+func (s *InventoryService) ListContext(ctx context.Context, productIDs []int) ([]ProductAggregatedInventory, error) {
+	if len(productIDs) == 0 {
+		return listAll(ctx, &QueryParams{}, s.listPageContext)
+	}
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, value)
-	if err != nil {
-		return nil, err
+	var all []ProductAggregatedInventory
+	for start := 0; start < len(productIDs); start += idInChunkSize {
+		end := start + idInChunkSize
+		if end > len(productIDs) {
+			end = len(productIDs)
+		}
+
+		inventories, err := listAll(ctx, &QueryParams{IDIn: productIDs[start:end]}, s.listPageContext)
+		all = append(all, inventories...)
+		if err != nil {
+			return all, err
+		}
 	}
 
-	valueResponse := new(OptionValueResponse)
-	_, err = s.client.Do(req, valueResponse)
-	return valueResponse, err
+	return all, nil
 }
 
-func (s *ModifiersService) DeleteModifierValueContext(ctx context.Context, productID, modifierID, valueID int) error {
-	path := fmt.Sprintf("catalog/products/%d/modifiers/%d/values/%d", productID, modifierID, valueID)
+func (s *InventoryService) listPageContext(ctx context.Context, params *QueryParams) ([]ProductAggregatedInventory, Meta, error) {
+	path := "catalog/products/inventory"
 
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
-		return err
+		return nil, Meta{}, err
 	}
 
-	_, err = s.client.Do(req, nil)
-	return err
+	req.URL.RawQuery = params.ToValues().Encode()
+
+	inventoriesResponse := new(ProductInventoriesResponse)
+	if _, err := s.client.Do(req, inventoriesResponse); err != nil {
+		return nil, Meta{}, err
+	}
+	return inventoriesResponse.Data, inventoriesResponse.Meta, nil
 }
 
-type OptionsService struct {
+type BulkPricingRulesService struct {
 	client *Client
 }
 
-func (s *OptionsService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ProductOptionsResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/options", productID)
+func (s *BulkPricingRulesService) ListContext(ctx context.Context, productID int, params *QueryParams) (*PricingRulesResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules", productID)
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -1488,52 +5451,55 @@ func (s *OptionsService) ListContext(ctx context.Context, productID int, params
 		req.URL.RawQuery = params.ToValues().Encode()
 	}
 
-	optionsResponse := new(ProductOptionsResponse)
-	_, err = s.client.Do(req, optionsResponse)
-	return optionsResponse, err
+	rulesResponse := new(PricingRulesResponse)
+	_, err = s.client.Do(req, rulesResponse)
+	return rulesResponse, err
 }
 
-func (s *OptionsService) GetContext(ctx context.Context, productID, optionID int) (*ProductOptionResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/options/%d", productID, optionID)
+func (s *BulkPricingRulesService) GetContext(ctx context.Context, productID, ruleID int) (*PricingRuleResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules/%d", productID, ruleID)
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	optionResponse := new(ProductOptionResponse)
-	_, err = s.client.Do(req, optionResponse)
-	return optionResponse, err
+	ruleResponse := new(PricingRuleResponse)
+	_, err = s.client.Do(req, ruleResponse)
+	if err == nil && ruleResponse.Data.ID == 0 {
+		err = emptyGet(path)
+	}
+	return ruleResponse, err
 }
 
-func (s *OptionsService) CreateContext(ctx context.Context, productID int, option *ProductOption) (*ProductOptionResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/options", productID)
+func (s *BulkPricingRulesService) CreateContext(ctx context.Context, productID int, rule *PricingRule) (*PricingRuleResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules", productID)
 
-	req, err := s.client.NewRequest(ctx, "POST", path, option)
+	req, err := s.client.NewRequest(ctx, "POST", path, rule)
 	if err != nil {
 		return nil, err
 	}
 
-	optionResponse := new(ProductOptionResponse)
-	_, err = s.client.Do(req, optionResponse)
-	return optionResponse, err
+	ruleResponse := new(PricingRuleResponse)
+	_, err = s.client.Do(req, ruleResponse)
+	return ruleResponse, err
 }
 
-func (s *OptionsService) UpdateContext(ctx context.Context, productID, optionID int, option *ProductOption) (*ProductOptionResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/options/%d", productID, optionID)
+func (s *BulkPricingRulesService) UpdateContext(ctx context.Context, productID, ruleID int, rule *PricingRule) (*PricingRuleResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules/%d", productID, ruleID)
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, option)
+	req, err := s.client.NewRequest(ctx, "PUT", path, rule)
 	if err != nil {
 		return nil, err
 	}
 
-	optionResponse := new(ProductOptionResponse)
-	_, err = s.client.Do(req, optionResponse)
-	return optionResponse, err
+	ruleResponse := new(PricingRuleResponse)
+	_, err = s.client.Do(req, ruleResponse)
+	return ruleResponse, err
 }
 
-func (s *OptionsService) DeleteContext(ctx context.Context, productID, optionID int) error {
-	path := fmt.Sprintf("catalog/products/%d/options/%d", productID, optionID)
+func (s *BulkPricingRulesService) DeleteContext(ctx context.Context, productID, ruleID int) error {
+	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules/%d", productID, ruleID)
 
 	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
@@ -1544,80 +5510,86 @@ func (s *OptionsService) DeleteContext(ctx context.Context, productID, optionID
 	return err
 }
 
-func (s *OptionsService) GetOptionValuesContext(ctx context.Context, productID, optionID int, params *QueryParams) (*OptionValuesResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/options/%d/values", productID, optionID)
+func (s *BulkPricingRulesService) UpdateBatchContext(ctx context.Context, productID int, request *BulkPricingRuleRequest) (*BulkPricingRuleResponse, error) {
+	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules", productID)
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	req, err := s.client.NewRequest(ctx, "PUT", path, request)
 	if err != nil {
 		return nil, err
 	}
 
-	if params != nil {
-		req.URL.RawQuery = params.ToValues().Encode()
-	}
-
-	valuesResponse := new(OptionValuesResponse)
-	_, err = s.client.Do(req, valuesResponse)
-	return valuesResponse, err
+	bulkResponse := new(BulkPricingRuleResponse)
+	_, err = s.client.Do(req, bulkResponse)
+	return bulkResponse, err
 }
 
-func (s *OptionsService) GetOptionValueContext(ctx context.Context, productID, optionID, valueID int) (*OptionValueResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/options/%d/values/%d", productID, optionID, valueID)
+func (s *BulkPricingRulesService) DeleteAllContext(ctx context.Context, productID int) error {
+	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules", productID)
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	valueResponse := new(OptionValueResponse)
-	_, err = s.client.Do(req, valueResponse)
-	return valueResponse, err
+	_, err = s.client.Do(req, nil)
+	return err
 }
 
-func (s *OptionsService) CreateOptionValueContext(ctx context.Context, productID, optionID int, value *OptionValue) (*OptionValueResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/options/%d/values", productID, optionID)
-
-	req, err := s.client.NewRequest(ctx, "POST", path, value)
-	if err != nil {
-		return nil, err
+// EffectivePrice applies the bulk pricing tier matching quantity to base.
+// A "price" rule takes its amount off each unit, "percent" takes that
+// percentage off, and "fixed" replaces the unit price outright. When tiers
+// overlap the one with the highest minimum wins.
+func EffectivePrice(base float64, rules []PricingRule, quantity int) float64 {
+	var match *PricingRule
+	for i := range rules {
+		rule := &rules[i]
+		if quantity < rule.QuantityMin {
+			continue
+		}
+		if rule.QuantityMax > 0 && quantity > rule.QuantityMax {
+			continue
+		}
+		if match == nil || rule.QuantityMin > match.QuantityMin {
+			match = rule
+		}
 	}
 
-	valueResponse := new(OptionValueResponse)
-	_, err = s.client.Do(req, valueResponse)
-	return valueResponse, err
-}
+	if match == nil {
+		return base
+	}
 
-func (s *OptionsService) UpdateOptionValueContext(ctx context.Context, productID, optionID, valueID int, value *OptionValue) (*OptionValueResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/options/%d/values/%d", productID, optionID, valueID)
+	price := base
+	switch match.Type {
+	case "price":
+		price = base - match.Amount
+	case "percent":
+		price = base * (1 - match.Amount/100)
+	case "fixed":
+		price = match.Amount
+	}
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, value)
-	if err != nil {
-		return nil, err
+	if price < 0 {
+		return 0
 	}
 
-	valueResponse := new(OptionValueResponse)
-	_, err = s.client.Do(req, valueResponse)
-	return valueResponse, err
+	return price
 }
 
-func (s *OptionsService) DeleteOptionValueContext(ctx context.Context, productID, optionID, valueID int) error {
-	path := fmt.Sprintf("catalog/products/%d/options/%d/values/%d", productID, optionID, valueID)
-
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
-	if err != nil {
-		return err
-	}
+type BulkPricingRuleRequest struct {
+	BulkPricingRules []PricingRule `json:"bulk_pricing_rules"`
+}
 
-	_, err = s.client.Do(req, nil)
-	return err
+type BulkPricingRuleResponse struct {
+	Data []PricingRule `json:"data"`
+	Meta Meta          `json:"meta"`
 }
 
-type ProductsService struct {
+type WebhooksService struct {
 	client *Client
 }
 
-func (s *ProductsService) ListContext(ctx context.Context, params *QueryParams) (*ProductsResponse, error) {
-	path := "catalog/products"
+func (s *WebhooksService) ListContext(ctx context.Context, params *QueryParams) (*WebhooksResponse, error) {
+	path := "hooks"
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -1628,56 +5600,55 @@ func (s *ProductsService) ListContext(ctx context.Context, params *QueryParams)
 		req.URL.RawQuery = params.ToValues().Encode()
 	}
 
-	productsResponse := new(ProductsResponse)
-	_, err = s.client.Do(req, productsResponse)
-	return productsResponse, err
+	webhooksResponse := new(WebhooksResponse)
+	_, err = s.client.Do(req, webhooksResponse)
+	return webhooksResponse, err
 }
 
-func (s *ProductsService) GetContext(ctx context.Context, id int, params *QueryParams) (*ProductResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d", id)
+func (s *WebhooksService) GetContext(ctx context.Context, webhookID int) (*WebhookResponse, error) {
+	path := fmt.Sprintf("hooks/%d", webhookID)
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if params != nil {
-		req.URL.RawQuery = params.ToValues().Encode()
+	webhookResponse := new(WebhookResponse)
+	_, err = s.client.Do(req, webhookResponse)
+	if err == nil && webhookResponse.Data.ID == 0 {
+		err = emptyGet(path)
 	}
-
-	productResponse := new(ProductResponse)
-	_, err = s.client.Do(req, productResponse)
-	return productResponse, err
+	return webhookResponse, err
 }
 
-func (s *ProductsService) CreateContext(ctx context.Context, product *Product) (*ProductResponse, error) {
-	path := "catalog/products"
+func (s *WebhooksService) CreateContext(ctx context.Context, webhook *Webhook) (*WebhookResponse, error) {
+	path := "hooks"
 
-	req, err := s.client.NewRequest(ctx, "POST", path, product)
+	req, err := s.client.NewRequest(ctx, "POST", path, webhook)
 	if err != nil {
 		return nil, err
 	}
 
-	productResponse := new(ProductResponse)
-	_, err = s.client.Do(req, productResponse)
-	return productResponse, err
+	webhookResponse := new(WebhookResponse)
+	_, err = s.client.Do(req, webhookResponse)
+	return webhookResponse, err
 }
 
-func (s *ProductsService) UpdateContext(ctx context.Context, id int, product *Product) (*ProductResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d", id)
+func (s *WebhooksService) UpdateContext(ctx context.Context, webhookID int, webhook *Webhook) (*WebhookResponse, error) {
+	path := fmt.Sprintf("hooks/%d", webhookID)
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, product)
+	req, err := s.client.NewRequest(ctx, "PUT", path, webhook)
 	if err != nil {
 		return nil, err
 	}
 
-	productResponse := new(ProductResponse)
-	_, err = s.client.Do(req, productResponse)
-	return productResponse, err
+	webhookResponse := new(WebhookResponse)
+	_, err = s.client.Do(req, webhookResponse)
+	return webhookResponse, err
 }
 
-func (s *ProductsService) DeleteContext(ctx context.Context, id int) error {
-	path := fmt.Sprintf("catalog/products/%d", id)
+func (s *WebhooksService) DeleteContext(ctx context.Context, webhookID int) error {
+	path := fmt.Sprintf("hooks/%d", webhookID)
 
 	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
@@ -1688,101 +5659,202 @@ func (s *ProductsService) DeleteContext(ctx context.Context, id int) error {
 	return err
 }
 
-type ReviewsService struct {
-	client *Client
+type Webhook struct {
+	ID          int               `json:"id,omitempty"`
+	ClientID    string            `json:"client_id,omitempty"`
+	StoreHash   string            `json:"store_hash,omitempty"`
+	Scope       string            `json:"scope"`
+	Destination string            `json:"destination"`
+	IsActive    bool              `json:"is_active"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	CreatedAt   int64             `json:"created_at,omitempty"`
+	UpdatedAt   int64             `json:"updated_at,omitempty"`
 }
 
-func (s *ReviewsService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ReviewsResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/reviews", productID)
+type WebhookResponse struct {
+	Data Webhook `json:"data"`
+	Meta Meta    `json:"meta"`
+}
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
+type WebhooksResponse struct {
+	Data []Webhook `json:"data"`
+	Meta Meta      `json:"meta"`
+}
 
-	if params != nil {
-		req.URL.RawQuery = params.ToValues().Encode()
-	}
+const (
+	WebhookScopeProductCreated          = "store/product/created"
+	WebhookScopeProductUpdated          = "store/product/updated"
+	WebhookScopeProductDeleted          = "store/product/deleted"
+	WebhookScopeProductInventoryUpdated = "store/product/inventory/updated"
+	WebhookScopeSKUCreated              = "store/sku/created"
+	WebhookScopeSKUUpdated              = "store/sku/updated"
+	WebhookScopeSKUDeleted              = "store/sku/deleted"
+	WebhookScopeCategoryCreated         = "store/category/created"
+	WebhookScopeCategoryUpdated         = "store/category/updated"
+	WebhookScopeCategoryDeleted         = "store/category/deleted"
+	WebhookScopeOrderCreated            = "store/order/created"
+	WebhookScopeOrderUpdated            = "store/order/updated"
+	WebhookScopeOrderStatusUpdated      = "store/order/statusUpdated"
+	WebhookScopeCustomerCreated         = "store/customer/created"
+	WebhookScopeCustomerUpdated         = "store/customer/updated"
+	WebhookScopeCustomerDeleted         = "store/customer/deleted"
+	WebhookScopeCartCreated             = "store/cart/created"
+	WebhookScopeCartUpdated             = "store/cart/updated"
+	WebhookScopeCartAbandoned           = "store/cart/abandoned"
+	WebhookScopeAppUninstalled          = "store/app/uninstalled"
+)
 
-	reviewsResponse := new(ReviewsResponse)
-	_, err = s.client.Do(req, reviewsResponse)
-	return reviewsResponse, err
-}
+const (
+	webhookIDHeader        = "webhook-id"
+	webhookTimestampHeader = "webhook-timestamp"
+	webhookSignatureHeader = "webhook-signature"
+	webhookTolerance       = 5 * time.Minute
+)
 
-func (s *ReviewsService) GetContext(ctx context.Context, productID, reviewID int) (*ReviewResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/reviews/%d", productID, reviewID)
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+// VerifyWebhook checks a payload signed per the Standard Webhooks scheme:
+// an HMAC-SHA256 over "<webhook-id>.<webhook-timestamp>.<body>".
+func VerifyWebhook(secret string, header http.Header, body []byte) error {
+	id := header.Get(webhookIDHeader)
+	timestamp := header.Get(webhookTimestampHeader)
+	signatures := header.Get(webhookSignatureHeader)
+	if id == "" || timestamp == "" || signatures == "" {
+		return fmt.Errorf("%w: missing webhook headers", ErrInvalidWebhookSignature)
+	}
+
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("%w: bad timestamp %q", ErrInvalidWebhookSignature, timestamp)
+	}
+	if age := time.Since(time.Unix(sentAt, 0)); age > webhookTolerance || age < -webhookTolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidWebhookSignature)
 	}
 
-	reviewResponse := new(ReviewResponse)
-	_, err = s.client.Do(req, reviewResponse)
-	return reviewResponse, err
-}
+	key := []byte(secret)
+	if encoded, ok := strings.CutPrefix(secret, "whsec_"); ok {
+		key, err = base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("%w: bad secret encoding", ErrInvalidWebhookSignature)
+		}
+	}
 
-func (s *ReviewsService) CreateContext(ctx context.Context, productID int, review *Review) (*ReviewResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/reviews", productID)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
 
-	req, err := s.client.NewRequest(ctx, "POST", path, review)
-	if err != nil {
-		return nil, err
+	for _, signature := range strings.Fields(signatures) {
+		version, encoded, ok := strings.Cut(signature, ",")
+		if !ok || version != "v1" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return nil
+		}
 	}
 
-	reviewResponse := new(ReviewResponse)
-	_, err = s.client.Do(req, reviewResponse)
-	return reviewResponse, err
+	return ErrInvalidWebhookSignature
 }
 
-func (s *ReviewsService) UpdateContext(ctx context.Context, productID, reviewID int, review *Review) (*ReviewResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/reviews/%d", productID, reviewID)
-
-	req, err := s.client.NewRequest(ctx, "PUT", path, review)
-	if err != nil {
-		return nil, err
-	}
+type WebhookEvent struct {
+	Scope     string           `json:"scope"`
+	StoreID   string           `json:"store_id"`
+	Producer  string           `json:"producer"`
+	Hash      string           `json:"hash"`
+	CreatedAt int64            `json:"created_at"`
+	Data      WebhookEventData `json:"data"`
+}
 
-	reviewResponse := new(ReviewResponse)
-	_, err = s.client.Do(req, reviewResponse)
-	return reviewResponse, err
+type WebhookEventData struct {
+	Type string          `json:"type"`
+	ID   int             `json:"id"`
+	Raw  json.RawMessage `json:"-"`
 }
 
-func (s *ReviewsService) DeleteContext(ctx context.Context, productID, reviewID int) error {
-	path := fmt.Sprintf("catalog/products/%d/reviews/%d", productID, reviewID)
+func ParseWebhookPayload(body []byte) (*WebhookEvent, error) {
+	var payload struct {
+		WebhookEvent
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
 
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
-	if err != nil {
-		return err
+	event := payload.WebhookEvent
+	if len(payload.Data) > 0 {
+		if err := json.Unmarshal(payload.Data, &event.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook data: %w", err)
+		}
+		event.Data.Raw = payload.Data
 	}
 
-	_, err = s.client.Do(req, nil)
-	return err
+	if event.Scope == "" {
+		return nil, errors.New("webhook payload has no scope")
+	}
+
+	return &event, nil
 }
 
-type SummaryService struct {
-	client *Client
+type customerLoginClaims struct {
+	Issuer     string `json:"iss"`
+	IssuedAt   int64  `json:"iat"`
+	JTI        string `json:"jti"`
+	Operation  string `json:"operation"`
+	StoreHash  string `json:"store_hash"`
+	CustomerID int    `json:"customer_id"`
+	RedirectTo string `json:"redirect_to,omitempty"`
 }
 
-func (s *SummaryService) GetContext(ctx context.Context, productID int) (*SummaryResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/summary", productID)
+// GenerateCustomerLoginToken builds the HS256 JWT accepted by the
+// storefront at /login/token/{jwt}.
+func GenerateCustomerLoginToken(clientID, clientSecret string, storeHash string, customerID int, redirectTo string) (string, error) {
+	if clientID == "" || clientSecret == "" {
+		return "", errors.New("client id and secret are required")
+	}
+	if storeHash == "" {
+		return "", errors.New("store hash is required")
+	}
+	if customerID <= 0 {
+		return "", fmt.Errorf("invalid customer id %d", customerID)
+	}
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+
+	claims, err := json.Marshal(customerLoginClaims{
+		Issuer:     clientID,
+		IssuedAt:   time.Now().Unix(),
+		JTI:        newRequestID(),
+		Operation:  "customer_login",
+		StoreHash:  storeHash,
+		CustomerID: customerID,
+		RedirectTo: redirectTo,
+	})
+	if err != nil {
+		return "", err
 	}
 
-	summaryResponse := new(SummaryResponse)
-	_, err = s.client.Do(req, summaryResponse)
-	return summaryResponse, err
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	mac := hmac.New(sha256.New, []byte(clientSecret))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
 }
 
-type VariantsService struct {
+type TaxClassesService struct {
 	client *Client
 }
 
-func (s *VariantsService) ListContext(ctx context.Context, productID int, params *QueryParams) (*VariantsResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/variants", productID)
+func (s *TaxClassesService) ListContext(ctx context.Context, params *QueryParams) ([]TaxClass, error) {
+	path := v2Path("tax_classes")
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -1793,68 +5865,40 @@ func (s *VariantsService) ListContext(ctx context.Context, productID int, params
 		req.URL.RawQuery = params.ToValues().Encode()
 	}
 
-	variantsResponse := new(VariantsResponse)
-	_, err = s.client.Do(req, variantsResponse)
-	return variantsResponse, err
+	var taxClasses []TaxClass
+	_, err = s.client.Do(req, &taxClasses)
+	return taxClasses, err
 }
 
-func (s *VariantsService) GetContext(ctx context.Context, productID, variantID int) (*VariantResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/variants/%d", productID, variantID)
+func (s *TaxClassesService) GetContext(ctx context.Context, taxClassID int) (*TaxClass, error) {
+	path := v2Path(fmt.Sprintf("tax_classes/%d", taxClassID))
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	variantResponse := new(VariantResponse)
-	_, err = s.client.Do(req, variantResponse)
-	return variantResponse, err
-}
-
-func (s *VariantsService) CreateContext(ctx context.Context, productID int, variant *Variant) (*VariantResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/variants", productID)
-
-	req, err := s.client.NewRequest(ctx, "POST", path, variant)
-	if err != nil {
-		return nil, err
-	}
-
-	variantResponse := new(VariantResponse)
-	_, err = s.client.Do(req, variantResponse)
-	return variantResponse, err
-}
-
-func (s *VariantsService) UpdateContext(ctx context.Context, productID, variantID int, variant *Variant) (*VariantResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/variants/%d", productID, variantID)
-
-	req, err := s.client.NewRequest(ctx, "PUT", path, variant)
-	if err != nil {
-		return nil, err
+	taxClass := new(TaxClass)
+	_, err = s.client.Do(req, taxClass)
+	if err == nil && taxClass.ID == 0 {
+		err = emptyGet(path)
 	}
-
-	variantResponse := new(VariantResponse)
-	_, err = s.client.Do(req, variantResponse)
-	return variantResponse, err
+	return taxClass, err
 }
 
-func (s *VariantsService) DeleteContext(ctx context.Context, productID, variantID int) error {
-	path := fmt.Sprintf("catalog/products/%d/variants/%d", productID, variantID)
-
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.Do(req, nil)
-	return err
+type TaxClass struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
 }
 
-type VideosService struct {
+type GiftWrappingService struct {
 	client *Client
 }
 
-func (s *VideosService) ListContext(ctx context.Context, productID int, params *QueryParams) (*ProductVideosResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/videos", productID)
+func (s *GiftWrappingService) ListContext(ctx context.Context, params *QueryParams) ([]GiftWrappingOption, error) {
+	path := v2Path("gift_wrapping")
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -1865,52 +5909,55 @@ func (s *VideosService) ListContext(ctx context.Context, productID int, params *
 		req.URL.RawQuery = params.ToValues().Encode()
 	}
 
-	videosResponse := new(ProductVideosResponse)
-	_, err = s.client.Do(req, videosResponse)
-	return videosResponse, err
+	var options []GiftWrappingOption
+	_, err = s.client.Do(req, &options)
+	return options, err
 }
 
-func (s *VideosService) GetContext(ctx context.Context, productID, videoID int) (*ProductVideoResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/videos/%d", productID, videoID)
+func (s *GiftWrappingService) GetContext(ctx context.Context, optionID int) (*GiftWrappingOption, error) {
+	path := v2Path(fmt.Sprintf("gift_wrapping/%d", optionID))
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	videoResponse := new(ProductVideoResponse)
-	_, err = s.client.Do(req, videoResponse)
-	return videoResponse, err
+	option := new(GiftWrappingOption)
+	_, err = s.client.Do(req, option)
+	if err == nil && option.ID == 0 {
+		err = emptyGet(path)
+	}
+	return option, err
 }
 
-func (s *VideosService) CreateContext(ctx context.Context, productID int, video *ProductVideo) (*ProductVideoResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/videos", productID)
+func (s *GiftWrappingService) CreateContext(ctx context.Context, option *GiftWrappingOption) (*GiftWrappingOption, error) {
+	path := v2Path("gift_wrapping")
 
-	req, err := s.client.NewRequest(ctx, "POST", path, video)
+	req, err := s.client.NewRequest(ctx, "POST", path, option)
 	if err != nil {
 		return nil, err
 	}
 
-	videoResponse := new(ProductVideoResponse)
-	_, err = s.client.Do(req, videoResponse)
-	return videoResponse, err
+	created := new(GiftWrappingOption)
+	_, err = s.client.Do(req, created)
+	return created, err
 }
 
-func (s *VideosService) UpdateContext(ctx context.Context, productID, videoID int, video *ProductVideo) (*ProductVideoResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/videos/%d", productID, videoID)
+func (s *GiftWrappingService) UpdateContext(ctx context.Context, optionID int, option *GiftWrappingOption) (*GiftWrappingOption, error) {
+	path := v2Path(fmt.Sprintf("gift_wrapping/%d", optionID))
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, video)
+	req, err := s.client.NewRequest(ctx, "PUT", path, option)
 	if err != nil {
 		return nil, err
 	}
 
-	videoResponse := new(ProductVideoResponse)
-	_, err = s.client.Do(req, videoResponse)
-	return videoResponse, err
+	updated := new(GiftWrappingOption)
+	_, err = s.client.Do(req, updated)
+	return updated, err
 }
 
-func (s *VideosService) DeleteContext(ctx context.Context, productID, videoID int) error {
-	path := fmt.Sprintf("catalog/products/%d/videos/%d", productID, videoID)
+func (s *GiftWrappingService) DeleteContext(ctx context.Context, optionID int) error {
+	path := v2Path(fmt.Sprintf("gift_wrapping/%d", optionID))
 
 	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
@@ -1921,410 +5968,538 @@ func (s *VideosService) DeleteContext(ctx context.Context, productID, videoID in
 	return err
 }
 
-type ProductChannelAssignment struct {
-	ProductID int `json:"product_id"`
-	ChannelID int `json:"channel_id"`
+type GiftWrappingOption struct {
+	ID              int    `json:"id,omitempty"`
+	Name            string `json:"name"`
+	AllowComments   bool   `json:"allow_comments"`
+	PreviewImageURL string `json:"preview_image_url,omitempty"`
+	Price           string `json:"price"`
 }
 
-type ProductChannelAssignmentsResponse struct {
-	Data []ProductChannelAssignment `json:"data"`
-	Meta Meta                       `json:"meta"`
+type CartsService struct {
+	client *Client
 }
 
-type CategoryAssignment struct {
-	ProductID  int `json:"product_id"`
-	CategoryID int `json:"category_id"`
-}
+var cartIncludes = []string{"redirect_urls", "line_items.physical_items.options", "line_items.digital_items.options"}
 
-type CategoryAssignmentsResponse struct {
-	Data []CategoryAssignment `json:"data"`
-	Meta Meta                 `json:"meta"`
-}
+func (s *CartsService) GetContext(ctx context.Context, cartID string, params *QueryParams) (*CartResponse, error) {
+	path := fmt.Sprintf("carts/%s", url.PathEscape(cartID))
 
-type BatchErrorResponse struct {
-	Data []BatchError `json:"data"`
-	Meta Meta         `json:"meta"`
-}
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
 
-type BatchError struct {
-	Error       string `json:"error"`
-	ResourceID  int    `json:"resource_id,omitempty"`
-	ResourceURL string `json:"resource_url,omitempty"`
-	Status      int    `json:"status"`
-}
+	if params == nil {
+		params = &QueryParams{Include: cartIncludes}
+	}
+	req.URL.RawQuery = params.ToValues().Encode()
 
-type BatchProductDeleteRequest struct {
-	IDs []int `json:"product_ids"`
+	cartResponse := new(CartResponse)
+	_, err = s.client.Do(req, cartResponse)
+	if err == nil && cartResponse.Data.ID == "" {
+		err = emptyGet(path)
+	}
+	return cartResponse, err
 }
 
-type BatchProductCreateRequest struct {
-	Products []Product `json:"products"`
-}
+func (s *CartsService) GetAbandonedCartIDContext(ctx context.Context, token string) (string, error) {
+	path := fmt.Sprintf("abandoned-carts/%s", url.PathEscape(token))
 
-type BatchProductUpdateRequest struct {
-	Products []Product `json:"products"`
-}
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return "", err
+	}
 
-type BatchProductsResponse struct {
-	Data []Product `json:"data"`
-	Meta Meta      `json:"meta"`
+	abandonedResponse := new(AbandonedCartResponse)
+	_, err = s.client.Do(req, abandonedResponse)
+	return abandonedResponse.Data.CartID, err
 }
 
-type PricingRequest struct {
-	ProductIDs   []int                      `json:"product_ids,omitempty"`
-	VariantIDs   []int                      `json:"variant_ids,omitempty"`
-	IncludeTaxes bool                       `json:"include_taxes,omitempty"`
-	Currencies   []string                   `json:"currencies,omitempty"`
-	Context      PricingRequestContext      `json:"context,omitempty"`
-	Filters      PricingRequestFilters      `json:"filters,omitempty"`
-	Aggregations PricingRequestAggregations `json:"aggregations,omitempty"`
-}
+// GetAbandonedContext resolves a recovery token (from the abandoned cart
+// email or the store/cart/abandoned webhook) to the full cart, including
+// its line items and recovery URLs.
+func (s *CartsService) GetAbandonedContext(ctx context.Context, token string) (*CartResponse, error) {
+	cartID, err := s.GetAbandonedCartIDContext(ctx, token)
+	if err != nil {
+		return nil, err
+	}
 
-type PricingRequestContext struct {
-	ChannelID       int `json:"channel_id,omitempty"`
-	CustomerID      int `json:"customer_id,omitempty"`
-	CustomerGroupID int `json:"customer_group_id,omitempty"`
+	return s.GetContext(ctx, cartID, nil)
 }
 
-type PricingRequestFilters struct {
-	SalesTaxable bool `json:"sales_taxable,omitempty"`
-}
+// ListAbandonedContext resolves each recovery token and keeps the carts
+// created within the optional date_created range on params.
+func (s *CartsService) ListAbandonedContext(ctx context.Context, tokens []string, params *QueryParams) ([]Cart, error) {
+	var createdMin, createdMax time.Time
+	if params != nil {
+		var err error
+		if createdMin, err = parseOptionalTime(params.DateCreatedMin); err != nil {
+			return nil, err
+		}
+		if createdMax, err = parseOptionalTime(params.DateCreatedMax); err != nil {
+			return nil, err
+		}
+	}
 
-type PricingRequestAggregations struct {
-	TaxExcludedPriceMin bool `json:"tax_excluded_price_min,omitempty"`
-	TaxExcludedPriceMax bool `json:"tax_excluded_price_max,omitempty"`
-	TaxIncludedPriceMin bool `json:"tax_included_price_min,omitempty"`
-	TaxIncludedPriceMax bool `json:"tax_included_price_max,omitempty"`
-}
+	carts := make([]Cart, 0, len(tokens))
+	for _, token := range tokens {
+		cartResponse, err := s.GetAbandonedContext(ctx, token)
+		if err != nil {
+			return carts, fmt.Errorf("failed to get abandoned cart %s: %w", token, err)
+		}
 
-type PricingResponse struct {
-	Data PricingData `json:"data"`
-	Meta Meta        `json:"meta"`
-}
+		cart := cartResponse.Data
+		created, err := time.Parse(time.RFC3339, cart.CreatedTime)
+		if err == nil {
+			if !createdMin.IsZero() && created.Before(createdMin) {
+				continue
+			}
+			if !createdMax.IsZero() && created.After(createdMax) {
+				continue
+			}
+		}
 
-type PricingData struct {
-	Products     map[string]PricingProductData `json:"products,omitempty"`
-	Variants     map[string]PricingVariantData `json:"variants,omitempty"`
-	Aggregations PricingAggregationData        `json:"aggregations,omitempty"`
-}
+		carts = append(carts, cart)
+	}
 
-type PricingProductData struct {
-	PriceExcludingTax       float64       `json:"price_excluding_tax"`
-	PriceIncludingTax       float64       `json:"price_including_tax"`
-	TaxAmount               float64       `json:"tax_amount"`
-	RetailPriceExcludingTax float64       `json:"retail_price_excluding_tax"`
-	RetailPriceIncludingTax float64       `json:"retail_price_including_tax"`
-	RetailTaxAmount         float64       `json:"retail_tax_amount"`
-	SalePriceExcludingTax   float64       `json:"sale_price_excluding_tax"`
-	SalePriceIncludingTax   float64       `json:"sale_price_including_tax"`
-	SaleTaxAmount           float64       `json:"sale_tax_amount"`
-	MapPriceExcludingTax    float64       `json:"map_price_excluding_tax"`
-	MapPriceIncludingTax    float64       `json:"map_price_including_tax"`
-	MapTaxAmount            float64       `json:"map_tax_amount"`
-	BulkPricingTiers        []PricingTier `json:"bulk_pricing_tiers"`
-	Currency                string        `json:"currency"`
+	return carts, nil
 }
 
-type PricingVariantData struct {
-	PriceExcludingTax       float64       `json:"price_excluding_tax"`
-	PriceIncludingTax       float64       `json:"price_including_tax"`
-	TaxAmount               float64       `json:"tax_amount"`
-	RetailPriceExcludingTax float64       `json:"retail_price_excluding_tax"`
-	RetailPriceIncludingTax float64       `json:"retail_price_including_tax"`
-	RetailTaxAmount         float64       `json:"retail_tax_amount"`
-	SalePriceExcludingTax   float64       `json:"sale_price_excluding_tax"`
-	SalePriceIncludingTax   float64       `json:"sale_price_including_tax"`
-	SaleTaxAmount           float64       `json:"sale_tax_amount"`
-	MapPriceExcludingTax    float64       `json:"map_price_excluding_tax"`
-	MapPriceIncludingTax    float64       `json:"map_price_including_tax"`
-	MapTaxAmount            float64       `json:"map_tax_amount"`
-	BulkPricingTiers        []PricingTier `json:"bulk_pricing_tiers"`
-	Currency                string        `json:"currency"`
-}
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
 
-type PricingTier struct {
-	QuantityMin       int     `json:"quantity_min"`
-	QuantityMax       int     `json:"quantity_max,omitempty"`
-	Type              string  `json:"type"`
-	Amount            float64 `json:"amount"`
-	PriceExcludingTax float64 `json:"price_excluding_tax"`
-	PriceIncludingTax float64 `json:"price_including_tax"`
-	TaxAmount         float64 `json:"tax_amount"`
-}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid RFC3339 time %q: %w", value, err)
+	}
 
-type PricingAggregationData struct {
-	TaxExcludedPriceMin float64 `json:"tax_excluded_price_min,omitempty"`
-	TaxExcludedPriceMax float64 `json:"tax_excluded_price_max,omitempty"`
-	TaxIncludedPriceMin float64 `json:"tax_included_price_min,omitempty"`
-	TaxIncludedPriceMax float64 `json:"tax_included_price_max,omitempty"`
+	return t, nil
 }
 
-type ProductAggregatedInventory struct {
-	ProductID         int    `json:"product_id"`
-	InventoryLevel    int    `json:"inventory_level"`
-	InventoryWarning  int    `json:"inventory_warning_level"`
-	WarrantiesCount   int    `json:"warranties_count"`
-	VariantsCount     int    `json:"variants_count"`
-	InventoryTracking string `json:"inventory_tracking"`
+type Cart struct {
+	ID             string           `json:"id"`
+	CustomerID     int              `json:"customer_id"`
+	ChannelID      int              `json:"channel_id"`
+	Email          string           `json:"email,omitempty"`
+	Currency       CartCurrency     `json:"currency"`
+	TaxIncluded    bool             `json:"tax_included"`
+	BaseAmount     float64          `json:"base_amount"`
+	DiscountAmount float64          `json:"discount_amount"`
+	CartAmount     float64          `json:"cart_amount"`
+	LineItems      CartLineItems    `json:"line_items"`
+	CreatedTime    string           `json:"created_time"`
+	UpdatedTime    string           `json:"updated_time"`
+	RedirectURLs   CartRedirectURLs `json:"redirect_urls"`
 }
 
-type ProductInventoryResponse struct {
-	Data ProductAggregatedInventory `json:"data"`
-	Meta Meta                       `json:"meta"`
+type CartCurrency struct {
+	Code string `json:"code"`
 }
 
-type ProductInventoriesResponse struct {
-	Data []ProductAggregatedInventory `json:"data"`
-	Meta Meta                         `json:"meta"`
+type CartLineItems struct {
+	PhysicalItems []CartLineItem `json:"physical_items"`
+	DigitalItems  []CartLineItem `json:"digital_items"`
+	CustomItems   []CartLineItem `json:"custom_items"`
 }
 
-type RelatedProductsService struct {
-	client *Client
+type CartLineItem struct {
+	ID             string  `json:"id"`
+	ParentID       string  `json:"parent_id,omitempty"`
+	VariantID      int     `json:"variant_id"`
+	ProductID      int     `json:"product_id"`
+	SKU            string  `json:"sku"`
+	Name           string  `json:"name"`
+	URL            string  `json:"url"`
+	Quantity       int     `json:"quantity"`
+	IsTaxable      bool    `json:"is_taxable"`
+	ImageURL       string  `json:"image_url"`
+	ListPrice      float64 `json:"list_price"`
+	SalePrice      float64 `json:"sale_price"`
+	ExtendedPrice  float64 `json:"extended_sale_price"`
+	DiscountAmount float64 `json:"discount_amount"`
 }
 
-type ProductChannelAssignmentsService struct {
-	client *Client
+type CartRedirectURLs struct {
+	CartURL             string `json:"cart_url"`
+	CheckoutURL         string `json:"checkout_url"`
+	EmbeddedCheckoutURL string `json:"embedded_checkout_url"`
 }
 
-type ProductCategoriesService struct {
-	client *Client
+type CartResponse struct {
+	Data Cart `json:"data"`
+	Meta Meta `json:"meta"`
 }
 
-type BatchService struct {
-	client *Client
+type AbandonedCart struct {
+	CartID string `json:"cart_id"`
 }
 
-type PricingService struct {
-	client *Client
+type AbandonedCartResponse struct {
+	Data AbandonedCart `json:"data"`
+	Meta Meta          `json:"meta"`
 }
 
-type InventoryService struct {
+type BlogPostsService struct {
 	client *Client
 }
 
-func (s *RelatedProductsService) CreateContext(ctx context.Context, productID int, relatedProductIDs []int) (*http.Response, error) {
-	path := fmt.Sprintf("catalog/products/%d/related", productID)
-
-	type RelatedProductsRequest struct {
-		ProductIDs []int `json:"product_ids"`
-	}
+func (s *BlogPostsService) ListContext(ctx context.Context, params *QueryParams) ([]BlogPost, error) {
+	path := v2Path("blog/posts")
 
-	req, err := s.client.NewRequest(ctx, "POST", path, RelatedProductsRequest{ProductIDs: relatedProductIDs})
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(req, nil)
-}
-
-func (s *RelatedProductsService) DeleteContext(ctx context.Context, productID int) (*http.Response, error) {
-	path := fmt.Sprintf("catalog/products/%d/related", productID)
-
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
-	if err != nil {
-		return nil, err
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
 	}
 
-	return s.client.Do(req, nil)
+	var posts []BlogPost
+	_, err = s.client.Do(req, &posts)
+	return posts, err
 }
 
-func (s *RelatedProductsService) DeleteByIDContext(ctx context.Context, productID, relatedProductID int) (*http.Response, error) {
-	path := fmt.Sprintf("catalog/products/%d/related/%d", productID, relatedProductID)
+func (s *BlogPostsService) GetContext(ctx context.Context, postID int) (*BlogPost, error) {
+	path := v2Path(fmt.Sprintf("blog/posts/%d", postID))
 
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(req, nil)
+	post := new(BlogPost)
+	_, err = s.client.Do(req, post)
+	if err == nil && post.ID == 0 {
+		err = emptyGet(path)
+	}
+	return post, err
 }
 
-func (s *ProductChannelAssignmentsService) ListContext(ctx context.Context, productID int) (*ProductChannelAssignmentsResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/channels", productID)
+func (s *BlogPostsService) CreateContext(ctx context.Context, post *BlogPost) (*BlogPost, error) {
+	path := v2Path("blog/posts")
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
+	req, err := s.client.NewRequest(ctx, "POST", path, post)
 	if err != nil {
 		return nil, err
 	}
 
-	assignmentsResponse := new(ProductChannelAssignmentsResponse)
-	_, err = s.client.Do(req, assignmentsResponse)
-	return assignmentsResponse, err
+	created := new(BlogPost)
+	_, err = s.client.Do(req, created)
+	return created, err
 }
 
-func (s *ProductChannelAssignmentsService) CreateContext(ctx context.Context, productID int, channelIDs []int) (*http.Response, error) {
-	path := fmt.Sprintf("catalog/products/%d/channels", productID)
-
-	type ChannelAssignmentsRequest struct {
-		ChannelIDs []int `json:"channel_ids"`
-	}
+func (s *BlogPostsService) UpdateContext(ctx context.Context, postID int, post *BlogPost) (*BlogPost, error) {
+	path := v2Path(fmt.Sprintf("blog/posts/%d", postID))
 
-	req, err := s.client.NewRequest(ctx, "POST", path, ChannelAssignmentsRequest{ChannelIDs: channelIDs})
+	req, err := s.client.NewRequest(ctx, "PUT", path, post)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(req, nil)
+	updated := new(BlogPost)
+	_, err = s.client.Do(req, updated)
+	return updated, err
 }
 
-func (s *ProductChannelAssignmentsService) DeleteChannelContext(ctx context.Context, productID, channelID int) (*http.Response, error) {
-	path := fmt.Sprintf("catalog/products/%d/channels/%d", productID, channelID)
+func (s *BlogPostsService) DeleteContext(ctx context.Context, postID int) error {
+	path := v2Path(fmt.Sprintf("blog/posts/%d", postID))
 
 	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return s.client.Do(req, nil)
+	_, err = s.client.Do(req, nil)
+	return err
 }
 
-func (s *ProductCategoriesService) ListContext(ctx context.Context, productID int) (*CategoryAssignmentsResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/categories", productID)
-
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
+type BlogPost struct {
+	ID                   int      `json:"id,omitempty"`
+	Title                string   `json:"title"`
+	URL                  string   `json:"url,omitempty"`
+	PreviewURL           string   `json:"preview_url,omitempty"`
+	Body                 string   `json:"body"`
+	Tags                 []string `json:"tags,omitempty"`
+	Summary              string   `json:"summary,omitempty"`
+	IsPublished          bool     `json:"is_published"`
+	PublishedDate        BlogDate `json:"published_date,omitempty"`
+	PublishedDateISO8601 string   `json:"published_date_iso8601,omitempty"`
+	MetaDescription      string   `json:"meta_description,omitempty"`
+	MetaKeywords         string   `json:"meta_keywords,omitempty"`
+	Author               string   `json:"author,omitempty"`
+	ThumbnailPath        string   `json:"thumbnail_path,omitempty"`
+}
+
+// BlogDate accepts the v2 API's date object on read while still being
+// sent as a plain string on write.
+type BlogDate string
+
+func (d *BlogDate) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var date struct {
+			Date string `json:"date"`
+		}
+		if err := json.Unmarshal(data, &date); err != nil {
+			return err
+		}
+		*d = BlogDate(date.Date)
+		return nil
 	}
 
-	assignmentsResponse := new(CategoryAssignmentsResponse)
-	_, err = s.client.Do(req, assignmentsResponse)
-	return assignmentsResponse, err
+	var date string
+	if err := json.Unmarshal(data, &date); err != nil {
+		return err
+	}
+	*d = BlogDate(date)
+	return nil
 }
 
-func (s *ProductCategoriesService) CreateContext(ctx context.Context, productID int, categoryIDs []int) (*http.Response, error) {
-	path := fmt.Sprintf("catalog/products/%d/categories", productID)
-
-	type CategoryAssignmentsRequest struct {
-		CategoryIDs []int `json:"category_ids"`
+// ForEach runs fn for every item across at most concurrency goroutines and
+// returns the errors keyed by item. Items not yet started when ctx is
+// cancelled are recorded with the context's error.
+func ForEach[T comparable](ctx context.Context, items []T, concurrency int, fn func(context.Context, T) error) map[T]error {
+	if concurrency < 1 {
+		concurrency = 1
 	}
 
-	req, err := s.client.NewRequest(ctx, "POST", path, CategoryAssignmentsRequest{CategoryIDs: categoryIDs})
-	if err != nil {
-		return nil, err
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = make(map[T]error)
+		sem  = make(chan struct{}, concurrency)
+	)
+
+	record := func(item T, err error) {
+		mu.Lock()
+		errs[item] = err
+		mu.Unlock()
 	}
 
-	return s.client.Do(req, nil)
-}
+	for _, item := range items {
+		select {
+		case <-ctx.Done():
+			record(item, ctx.Err())
+			continue
+		case sem <- struct{}{}:
+		}
 
-func (s *ProductCategoriesService) DeleteContext(ctx context.Context, productID int) (*http.Response, error) {
-	path := fmt.Sprintf("catalog/products/%d/categories", productID)
+		wg.Add(1)
+		go func(item T) {
+			defer wg.Done()
+			defer func() { <-sem }()
 
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
-	if err != nil {
-		return nil, err
+			if err := ctx.Err(); err != nil {
+				record(item, err)
+				return
+			}
+			if err := fn(ctx, item); err != nil {
+				record(item, err)
+			}
+		}(item)
 	}
 
-	return s.client.Do(req, nil)
+	wg.Wait()
+
+	return errs
 }
 
-func (s *ProductCategoriesService) DeleteCategoryContext(ctx context.Context, productID, categoryID int) (*http.Response, error) {
-	path := fmt.Sprintf("catalog/products/%d/categories/%d", productID, categoryID)
+func ForEachProduct(ctx context.Context, ids []int, concurrency int, fn func(ctx context.Context, id int) error) map[int]error {
+	return ForEach(ctx, ids, concurrency, fn)
+}
 
-	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
-	if err != nil {
-		return nil, err
-	}
+type PriceListRecord struct {
+	PriceListID int     `json:"price_list_id,omitempty"`
+	VariantID   int     `json:"variant_id"`
+	ProductID   int     `json:"product_id,omitempty"`
+	SKU         string  `json:"sku,omitempty"`
+	Currency    string  `json:"currency"`
+	Price       float64 `json:"price"`
+	SalePrice   float64 `json:"sale_price,omitempty"`
+	RetailPrice float64 `json:"retail_price,omitempty"`
+	MapPrice    float64 `json:"map_price,omitempty"`
+}
 
-	return s.client.Do(req, nil)
+type PriceListRecordsResponse struct {
+	Data []PriceListRecord `json:"data"`
+	Meta Meta              `json:"meta"`
 }
 
-func (s *BatchService) CreateProductsContext(ctx context.Context, products []Product) (*BatchProductsResponse, error) {
-	path := "catalog/products"
+type PriceListRecordsService struct {
+	client *Client
+}
+
+func (s *PriceListRecordsService) ListContext(ctx context.Context, priceListID int, params *QueryParams) (*PriceListRecordsResponse, error) {
+	path := fmt.Sprintf("pricelists/%d/records", priceListID)
 
-	req, err := s.client.NewRequest(ctx, "POST", path, BatchProductCreateRequest{Products: products})
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	batchResponse := new(BatchProductsResponse)
-	_, err = s.client.Do(req, batchResponse)
-	return batchResponse, err
-}
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
 
-func (s *BatchService) UpdateProductsContext(ctx context.Context, products []Product) (*BatchProductsResponse, error) {
-	path := "catalog/products"
+	recordsResponse := new(PriceListRecordsResponse)
+	_, err = s.client.Do(req, recordsResponse)
+	return recordsResponse, err
+}
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, BatchProductUpdateRequest{Products: products})
-	if err != nil {
-		return nil, err
-	}
+// priceListRecordBatchSize is the most records the batch upsert endpoint
+// accepts in one request.
+const priceListRecordBatchSize = 1000
 
-	batchResponse := new(BatchProductsResponse)
-	_, err = s.client.Do(req, batchResponse)
-	return batchResponse, err
+// PriceListRecordError is a record the batch upsert rejected.
+type PriceListRecordError struct {
+	Record PriceListRecord
+	Err    error
 }
 
-func (s *BatchService) DeleteProductsContext(ctx context.Context, productIDs []int) (*BatchErrorResponse, error) {
-	path := "catalog/products"
+// UpsertBatchContext creates or updates records on a price list, which the
+// API matches by variant_id and currency. A later record for the same
+// variant and currency replaces an earlier one before anything is sent.
+// Records are sent in chunks of priceListRecordBatchSize; a chunk that
+// fails validation reports each rejected record, and a chunk that fails
+// outright reports all of its records. The error is non-nil when any
+// record failed. Once a WithBudget context runs out no further chunks are
+// sent and the error wraps ErrBudgetExhausted.
+func (s *PriceListRecordsService) UpsertBatchContext(ctx context.Context, priceListID int, records []PriceListRecord) ([]PriceListRecordError, error) {
+	path := fmt.Sprintf("pricelists/%d/records", priceListID)
 
-	req, err := s.client.NewRequest(ctx, "DELETE", path, BatchProductDeleteRequest{IDs: productIDs})
-	if err != nil {
-		return nil, err
+	type recordKey struct {
+		variantID int
+		currency  string
+	}
+	positions := make(map[recordKey]int, len(records))
+	deduped := make([]PriceListRecord, 0, len(records))
+	for _, record := range records {
+		key := recordKey{record.VariantID, strings.ToLower(record.Currency)}
+		if i, ok := positions[key]; ok {
+			deduped[i] = record
+			continue
+		}
+		positions[key] = len(deduped)
+		deduped = append(deduped, record)
 	}
 
-	batchResponse := new(BatchErrorResponse)
-	_, err = s.client.Do(req, batchResponse)
-	return batchResponse, err
-}
+	var failed []PriceListRecordError
+	for start := 0; start < len(deduped); start += priceListRecordBatchSize {
+		end := start + priceListRecordBatchSize
+		if end > len(deduped) {
+			end = len(deduped)
+		}
+		chunk := deduped[start:end]
 
-func (s *PricingService) GetContext(ctx context.Context, request PricingRequest) (*PricingResponse, error) {
-	path := "pricing/products"
+		if err := budgetError(ctx, ctx.Err()); errors.Is(err, ErrBudgetExhausted) {
+			return failed, fmt.Errorf("%d of %d price list records not sent: %w", len(deduped)-start, len(deduped), err)
+		}
 
-	req, err := s.client.NewRequest(ctx, "POST", path, request)
-	if err != nil {
-		return nil, err
-	}
+		req, err := s.client.NewRequest(ctx, "PUT", path, chunk)
+		if err != nil {
+			return failed, err
+		}
 
-	pricingResponse := new(PricingResponse)
-	_, err = s.client.Do(req, pricingResponse)
-	return pricingResponse, err
-}
+		_, err = s.client.Do(req, nil)
+		if err == nil {
+			continue
+		}
 
-func (s *InventoryService) GetContext(ctx context.Context, productID int) (*ProductInventoryResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/inventory", productID)
+		var recordErrors []PriceListRecordError
+		var errorResponse *ErrorResponse
+		if errors.As(err, &errorResponse) {
+			recordErrors = recordFieldErrors(chunk, errorResponse.FieldErrors)
+		}
+		if len(recordErrors) == 0 {
+			for _, record := range chunk {
+				recordErrors = append(recordErrors, PriceListRecordError{Record: record, Err: err})
+			}
+		}
+		failed = append(failed, recordErrors...)
+	}
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
+	if len(failed) > 0 {
+		return failed, fmt.Errorf("%d of %d price list records failed", len(failed), len(deduped))
 	}
 
-	inventoryResponse := new(ProductInventoryResponse)
-	_, err = s.client.Do(req, inventoryResponse)
-	return inventoryResponse, err
+	return nil, nil
 }
 
-func (s *InventoryService) ListContext(ctx context.Context, productIDs []int) (*ProductInventoriesResponse, error) {
-	path := "catalog/products/inventory"
-
-	values := make(map[string][]string)
-	for _, id := range productIDs {
-		values["id:in"] = append(values["id:in"], strconv.Itoa(id))
+// recordFieldErrors groups "<index>.<field>" validation errors by the
+// record they belong to.
+func recordFieldErrors(chunk []PriceListRecord, fieldErrors map[string]string) []PriceListRecordError {
+	messages := make(map[int][]string)
+	for key, message := range fieldErrors {
+		index, field, ok := strings.Cut(key, ".")
+		i, err := strconv.Atoi(index)
+		if !ok || err != nil || i < 0 || i >= len(chunk) {
+			continue
+		}
+		messages[i] = append(messages[i], field+": "+message)
 	}
 
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
+	indexes := make([]int, 0, len(messages))
+	for i := range messages {
+		indexes = append(indexes, i)
 	}
+	sort.Ints(indexes)
 
-	query := req.URL.Query()
-	for key, vals := range values {
-		for _, val := range vals {
-			query.Add(key, val)
-		}
+	failed := make([]PriceListRecordError, 0, len(indexes))
+	for _, i := range indexes {
+		sort.Strings(messages[i])
+		failed = append(failed, PriceListRecordError{
+			Record: chunk[i],
+			Err:    errors.New(strings.Join(messages[i], "; ")),
+		})
 	}
-	req.URL.RawQuery = query.Encode()
+	return failed
+}
 
-	inventoriesResponse := new(ProductInventoriesResponse)
-	_, err = s.client.Do(req, inventoriesResponse)
-	return inventoriesResponse, err
+const (
+	CustomerAttributeTypeString = "string"
+	CustomerAttributeTypeNumber = "number"
+	CustomerAttributeTypeDate   = "date"
+)
+
+type CustomerAttribute struct {
+	ID           int    `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	DateCreated  string `json:"date_created,omitempty"`
+	DateModified string `json:"date_modified,omitempty"`
 }
 
-type BulkPricingRulesService struct {
+type CustomerAttributesResponse struct {
+	Data []CustomerAttribute `json:"data"`
+	Meta Meta                `json:"meta"`
+}
+
+type CustomerAttributeValue struct {
+	ID           int    `json:"id,omitempty"`
+	AttributeID  int    `json:"attribute_id"`
+	CustomerID   int    `json:"customer_id"`
+	Value        string `json:"value"`
+	DateCreated  string `json:"date_created,omitempty"`
+	DateModified string `json:"date_modified,omitempty"`
+}
+
+type CustomerAttributeValuesResponse struct {
+	Data []CustomerAttributeValue `json:"data"`
+	Meta Meta                     `json:"meta"`
+}
+
+// CustomerAttributesService manages the custom fields the customers API
+// attaches to customer accounts. Writes on these endpoints take and return
+// arrays, so every mutation is a batch.
+type CustomerAttributesService struct {
 	client *Client
 }
 
-func (s *BulkPricingRulesService) ListContext(ctx context.Context, productID int, params *QueryParams) (*PricingRulesResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules", productID)
+func (s *CustomerAttributesService) ListContext(ctx context.Context, params *QueryParams) (*CustomerAttributesResponse, error) {
+	path := "customers/attributes"
 
 	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -2335,92 +6510,117 @@ func (s *BulkPricingRulesService) ListContext(ctx context.Context, productID int
 		req.URL.RawQuery = params.ToValues().Encode()
 	}
 
-	rulesResponse := new(PricingRulesResponse)
-	_, err = s.client.Do(req, rulesResponse)
-	return rulesResponse, err
+	attributesResponse := new(CustomerAttributesResponse)
+	_, err = s.client.Do(req, attributesResponse)
+	return attributesResponse, err
 }
 
-func (s *BulkPricingRulesService) GetContext(ctx context.Context, productID, ruleID int) (*PricingRuleResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules/%d", productID, ruleID)
-
-	req, err := s.client.NewRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	ruleResponse := new(PricingRuleResponse)
-	_, err = s.client.Do(req, ruleResponse)
-	return ruleResponse, err
+func (s *CustomerAttributesService) ListAllContext(ctx context.Context, params *QueryParams) ([]CustomerAttribute, error) {
+	return listAll(ctx, params, func(ctx context.Context, params *QueryParams) ([]CustomerAttribute, Meta, error) {
+		attributesResponse, err := s.ListContext(ctx, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return attributesResponse.Data, attributesResponse.Meta, nil
+	})
 }
 
-func (s *BulkPricingRulesService) CreateContext(ctx context.Context, productID int, rule *PricingRule) (*PricingRuleResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules", productID)
+func (s *CustomerAttributesService) CreateContext(ctx context.Context, attributes []CustomerAttribute) (*CustomerAttributesResponse, error) {
+	path := "customers/attributes"
 
-	req, err := s.client.NewRequest(ctx, "POST", path, rule)
+	req, err := s.client.NewRequest(ctx, "POST", path, attributes)
 	if err != nil {
 		return nil, err
 	}
 
-	ruleResponse := new(PricingRuleResponse)
-	_, err = s.client.Do(req, ruleResponse)
-	return ruleResponse, err
+	attributesResponse := new(CustomerAttributesResponse)
+	_, err = s.client.Do(req, attributesResponse)
+	return attributesResponse, err
 }
 
-func (s *BulkPricingRulesService) UpdateContext(ctx context.Context, productID, ruleID int, rule *PricingRule) (*PricingRuleResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules/%d", productID, ruleID)
+// UpdateContext renames attributes; each must carry its ID. The API does not
+// allow an attribute's type to change once created.
+func (s *CustomerAttributesService) UpdateContext(ctx context.Context, attributes []CustomerAttribute) (*CustomerAttributesResponse, error) {
+	path := "customers/attributes"
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, rule)
+	req, err := s.client.NewRequest(ctx, "PUT", path, attributes)
 	if err != nil {
 		return nil, err
 	}
 
-	ruleResponse := new(PricingRuleResponse)
-	_, err = s.client.Do(req, ruleResponse)
-	return ruleResponse, err
+	attributesResponse := new(CustomerAttributesResponse)
+	_, err = s.client.Do(req, attributesResponse)
+	return attributesResponse, err
 }
 
-func (s *BulkPricingRulesService) DeleteContext(ctx context.Context, productID, ruleID int) error {
-	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules/%d", productID, ruleID)
+// DeleteContext deletes attributes along with every value stored for them.
+func (s *CustomerAttributesService) DeleteContext(ctx context.Context, attributeIDs []int) error {
+	path := "customers/attributes"
 
 	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return err
 	}
 
+	req.URL.RawQuery = (&QueryParams{IDIn: attributeIDs}).ToValues().Encode()
+
 	_, err = s.client.Do(req, nil)
 	return err
 }
 
-func (s *BulkPricingRulesService) UpdateBatchContext(ctx context.Context, productID int, request *BulkPricingRuleRequest) (*BulkPricingRuleResponse, error) {
-	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules", productID)
+func (s *CustomerAttributesService) ListValuesContext(ctx context.Context, params *QueryParams) (*CustomerAttributeValuesResponse, error) {
+	path := "customers/attribute-values"
 
-	req, err := s.client.NewRequest(ctx, "PUT", path, request)
+	req, err := s.client.NewRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	bulkResponse := new(BulkPricingRuleResponse)
-	_, err = s.client.Do(req, bulkResponse)
-	return bulkResponse, err
+	if params != nil {
+		req.URL.RawQuery = params.ToValues().Encode()
+	}
+
+	valuesResponse := new(CustomerAttributeValuesResponse)
+	_, err = s.client.Do(req, valuesResponse)
+	return valuesResponse, err
 }
 
-func (s *BulkPricingRulesService) DeleteAllContext(ctx context.Context, productID int) error {
-	path := fmt.Sprintf("catalog/products/%d/bulk-pricing-rules", productID)
+func (s *CustomerAttributesService) ListAllValuesContext(ctx context.Context, params *QueryParams) ([]CustomerAttributeValue, error) {
+	return listAll(ctx, params, func(ctx context.Context, params *QueryParams) ([]CustomerAttributeValue, Meta, error) {
+		valuesResponse, err := s.ListValuesContext(ctx, params)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		return valuesResponse.Data, valuesResponse.Meta, nil
+	})
+}
+
+// UpsertValuesContext sets attribute values on customers. The API matches
+// existing values by attribute and customer, so the same call both creates
+// and updates.
+func (s *CustomerAttributesService) UpsertValuesContext(ctx context.Context, values []CustomerAttributeValue) (*CustomerAttributeValuesResponse, error) {
+	path := "customers/attribute-values"
+
+	req, err := s.client.NewRequest(ctx, "PUT", path, values)
+	if err != nil {
+		return nil, err
+	}
+
+	valuesResponse := new(CustomerAttributeValuesResponse)
+	_, err = s.client.Do(req, valuesResponse)
+	return valuesResponse, err
+}
+
+func (s *CustomerAttributesService) DeleteValuesContext(ctx context.Context, valueIDs []int) error {
+	path := "customers/attribute-values"
 
 	req, err := s.client.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return err
 	}
 
+	req.URL.RawQuery = (&QueryParams{IDIn: valueIDs}).ToValues().Encode()
+
 	_, err = s.client.Do(req, nil)
 	return err
 }
-
-type BulkPricingRuleRequest struct {
-	BulkPricingRules []PricingRule `json:"bulk_pricing_rules"`
-}
-
-type BulkPricingRuleResponse struct {
-	Data []PricingRule `json:"data"`
-	Meta Meta          `json:"meta"`
-}
@@ -0,0 +1,858 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client whose requests go to handler instead of
+// the BigCommerce API.
+func newTestClient(t *testing.T, handler http.Handler, opts ...ClientOption) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient("store", "token", opts...)
+	baseURL, err := url.Parse(server.URL + "/stores/store/v3/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.baseURL = baseURL
+	return client
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, v interface{}) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Error(err)
+	}
+}
+
+func pageMeta(page, totalPages int) Meta {
+	var meta Meta
+	meta.Pagination.CurrentPage = page
+	meta.Pagination.TotalPages = totalPages
+	return meta
+}
+
+func TestInventoryServiceListContextChunksAndPages(t *testing.T) {
+	const perPage = 40
+
+	var (
+		mu     sync.Mutex
+		chunks = make(map[string]int)
+	)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		ids := query["id:in"]
+		if len(ids) > idInChunkSize {
+			t.Errorf("request has %d IDs, more than %d", len(ids), idInChunkSize)
+		}
+
+		page, _ := strconv.Atoi(query.Get("page"))
+		totalPages := (len(ids) + perPage - 1) / perPage
+		mu.Lock()
+		chunks[ids[0]]++
+		mu.Unlock()
+
+		var data []ProductAggregatedInventory
+		for _, id := range ids[(page-1)*perPage : min(page*perPage, len(ids))] {
+			productID, _ := strconv.Atoi(id)
+			data = append(data, ProductAggregatedInventory{ProductID: productID, InventoryLevel: productID * 2})
+		}
+		writeJSON(t, w, http.StatusOK, ProductInventoriesResponse{Data: data, Meta: pageMeta(page, totalPages)})
+	}))
+
+	productIDs := make([]int, 2*idInChunkSize+50)
+	for i := range productIDs {
+		productIDs[i] = i + 1
+	}
+
+	inventories, err := client.Inventory.ListContext(t.Context(), productIDs)
+	if err != nil {
+		t.Fatalf("ListContext() error = %v", err)
+	}
+
+	got := make([]int, len(inventories))
+	for i, inventory := range inventories {
+		got[i] = inventory.ProductID
+		if inventory.InventoryLevel != inventory.ProductID*2 {
+			t.Errorf("product %d has inventory level %d, want %d", inventory.ProductID, inventory.InventoryLevel, inventory.ProductID*2)
+		}
+	}
+	sort.Ints(got)
+	if len(got) != len(productIDs) {
+		t.Fatalf("ListContext() returned %d inventories, want %d", len(got), len(productIDs))
+	}
+	for i := range got {
+		if got[i] != productIDs[i] {
+			t.Fatalf("ListContext() returned product %d at %d, want %d", got[i], i, productIDs[i])
+		}
+	}
+
+	// 100, 100 and 50 IDs at 40 per page
+	want := map[string]int{"1": 3, "101": 3, "201": 2}
+	if len(chunks) != len(want) {
+		t.Errorf("requested %d chunks, want %d", len(chunks), len(want))
+	}
+	for first, pages := range want {
+		if chunks[first] != pages {
+			t.Errorf("chunk starting at %s took %d pages, want %d", first, chunks[first], pages)
+		}
+	}
+}
+
+func TestInventoryServiceListContextWithoutIDsListsAll(t *testing.T) {
+	var requests int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if ids := r.URL.Query()["id:in"]; len(ids) > 0 {
+			t.Errorf("request filters by id:in %v", ids)
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		data := []ProductAggregatedInventory{{ProductID: page}}
+		writeJSON(t, w, http.StatusOK, ProductInventoriesResponse{Data: data, Meta: pageMeta(page, 2)})
+	}))
+
+	inventories, err := client.Inventory.ListContext(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("ListContext() error = %v", err)
+	}
+	if len(inventories) != 2 || requests != 2 {
+		t.Errorf("ListContext() returned %d inventories in %d requests, want 2 in 2", len(inventories), requests)
+	}
+}
+
+// digitalProductJSON is a digital product as the v3 API returns it, less
+// fields at their zero value, which Product omits when encoding.
+const digitalProductJSON = `{
+	"id": 112,
+	"name": "Field Guide eBook",
+	"type": "digital",
+	"sku": "EBOOK-1",
+	"description": "<p>The complete guide.</p>",
+	"weight": 0.01,
+	"price": 19.99,
+	"categories": [23],
+	"brand_id": 36,
+	"inventory_tracking": "none",
+	"is_visible": true,
+	"availability": "available",
+	"availability_description": "Available to download immediately after purchase",
+	"gift_wrapping_options_type": "none",
+	"total_sold": 4,
+	"date_created": "2024-01-02T03:04:05+00:00",
+	"date_modified": "2024-01-03T03:04:05+00:00",
+	"date_last_imported": "2024-01-01T03:04:05+00:00",
+	"custom_url": {"url": "/field-guide-ebook/", "is_customized": true}
+}`
+
+func TestDigitalProductRoundTrip(t *testing.T) {
+	var product Product
+	if err := json.Unmarshal([]byte(digitalProductJSON), &product); err != nil {
+		t.Fatal(err)
+	}
+	if product.Type != ProductTypeDigital {
+		t.Errorf("Type = %q, want %q", product.Type, ProductTypeDigital)
+	}
+
+	encoded, err := json.Marshal(product)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want, got map[string]interface{}
+	if err := json.Unmarshal([]byte(digitalProductJSON), &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range want {
+		if !reflect.DeepEqual(got[key], value) {
+			t.Errorf("%s = %v after a round trip, want %v", key, got[key], value)
+		}
+	}
+}
+
+func TestCreateDeepContextKeepsDigitalAttributes(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var sent map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Fatal(err)
+		}
+		for key, want := range map[string]interface{}{
+			"type":                       ProductTypeDigital,
+			"weight":                     0.01,
+			"inventory_tracking":         InventoryTrackingNone,
+			"gift_wrapping_options_type": "none",
+		} {
+			if sent[key] != want {
+				t.Errorf("sent %s = %v, want %v", key, sent[key], want)
+			}
+		}
+		for _, key := range []string{"width", "depth", "height", "inventory_level", "fixed_cost_shipping_price"} {
+			if _, ok := sent[key]; ok {
+				t.Errorf("sent %s = %v for a digital product", key, sent[key])
+			}
+		}
+
+		sent["id"] = 112
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"data": sent})
+	}))
+
+	product := &Product{Name: "Field Guide eBook", Type: ProductTypePhysical, Width: 10, Depth: 2, Height: 15, InventoryLevel: 5, Price: 19.99}
+	makeDigital(product)
+
+	response, err := client.Products.CreateDeepContext(t.Context(), product)
+	if err != nil {
+		t.Fatalf("CreateDeepContext() error = %v", err)
+	}
+
+	created := response.Data
+	created.ID = 0
+	if !reflect.DeepEqual(&created, product) {
+		t.Errorf("created product = %+v, want %+v", created, *product)
+	}
+}
+
+func TestQueryParamsCloneIsDeep(t *testing.T) {
+	visible := true
+	original := &QueryParams{Page: 2, Include: []string{"images"}, IDIn: []int{1, 2}, CategoryID: []int{3}, IsVisible: &visible}
+
+	clone := original.Clone()
+	if !reflect.DeepEqual(clone, original) {
+		t.Fatalf("Clone() = %+v, want %+v", clone, original)
+	}
+
+	clone.Include[0] = "variants"
+	clone.IDIn[0] = 9
+	clone.CategoryID[0] = 9
+	*clone.IsVisible = false
+
+	if original.Include[0] != "images" || original.IDIn[0] != 1 || original.CategoryID[0] != 3 || !*original.IsVisible {
+		t.Errorf("mutating the clone changed the original to %+v", original)
+	}
+
+	if (*QueryParams)(nil).Clone() != nil {
+		t.Error("Clone() of nil is not nil")
+	}
+}
+
+func TestQueryParamsMerge(t *testing.T) {
+	visible, hidden := true, false
+
+	tests := []struct {
+		name     string
+		base     *QueryParams
+		override *QueryParams
+		want     *QueryParams
+	}{
+		{"both nil", nil, nil, nil},
+		{"nil base", nil, &QueryParams{Limit: 5}, &QueryParams{Limit: 5}},
+		{"nil override", &QueryParams{Limit: 5}, nil, &QueryParams{Limit: 5}},
+		{
+			"override wins where set",
+			&QueryParams{Page: 1, Limit: 50, Sort: "name", Include: []string{"images"}, IsVisible: &visible},
+			&QueryParams{Limit: 10, Include: []string{"variants"}, IsVisible: &hidden},
+			&QueryParams{Page: 1, Limit: 10, Sort: "name", Include: []string{"variants"}, IsVisible: &hidden},
+		},
+		{
+			"empty override slice replaces",
+			&QueryParams{IDIn: []int{1, 2}},
+			&QueryParams{IDIn: []int{}},
+			&QueryParams{IDIn: []int{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.base.Merge(tt.override); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Merge() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryParamsMergeReturnsCopy(t *testing.T) {
+	visible := true
+	for _, base := range []*QueryParams{nil, {Page: 1}} {
+		override := &QueryParams{Include: []string{"images"}, IDIn: []int{1}, IsVisible: &visible}
+
+		merged := base.Merge(override)
+		if merged == override {
+			t.Fatalf("Merge() with base %+v returned override itself", base)
+		}
+		merged.Include[0] = "variants"
+		merged.IDIn[0] = 9
+		*merged.IsVisible = false
+
+		if override.Include[0] != "images" || override.IDIn[0] != 1 || !*override.IsVisible {
+			t.Errorf("mutating the merge of %+v changed override to %+v", base, override)
+		}
+	}
+}
+
+func TestProductValidateDoesNotModify(t *testing.T) {
+	tests := []struct {
+		name    string
+		product Product
+		wantErr bool
+	}{
+		{"valid", Product{Name: "Mug", Type: ProductTypePhysical, Price: 10, Categories: []int{3, 1}}, false},
+		{"repeated categories", Product{Name: "Mug", Type: ProductTypePhysical, Categories: []int{3, 1, 3}}, true},
+		{"invalid category", Product{Name: "Mug", Type: ProductTypePhysical, Categories: []int{3, 0, -2}}, true},
+		{"missing type", Product{Name: "Mug"}, true},
+		{"negative price", Product{Name: "Mug", Type: ProductTypeDigital, Price: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := tt.product
+			product.Categories = append([]int(nil), tt.product.Categories...)
+
+			if err := product.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, want error: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(product, tt.product) {
+				t.Errorf("Validate() changed the product to %+v", product)
+			}
+		})
+	}
+}
+
+func TestProductNormalizeCategories(t *testing.T) {
+	tests := []struct {
+		categories []int
+		want       []int
+	}{
+		{nil, nil},
+		{[]int{1, 2}, []int{1, 2}},
+		{[]int{3, 1, 3, 2, 1}, []int{3, 1, 2}},
+	}
+	for _, tt := range tests {
+		product := Product{Categories: append([]int(nil), tt.categories...)}
+		product.NormalizeCategories()
+		if len(product.Categories) != len(tt.want) || (len(tt.want) > 0 && !reflect.DeepEqual(product.Categories, tt.want)) {
+			t.Errorf("NormalizeCategories(%v) = %v, want %v", tt.categories, product.Categories, tt.want)
+		}
+	}
+}
+
+func TestMetafieldNormalizeThenValidate(t *testing.T) {
+	metafield := Metafield{Namespace: " generator ", Key: "seed ", Value: "1", Permission: " App_Only"}
+
+	if err := metafield.Validate(); err == nil {
+		t.Error("Validate() accepted a permission before Normalize")
+	}
+	if metafield.Permission != " App_Only" {
+		t.Errorf("Validate() changed the permission to %q", metafield.Permission)
+	}
+
+	metafield.Normalize()
+	if metafield.Namespace != "generator" || metafield.Key != "seed" || metafield.Permission != MetafieldPermissionAppOnly {
+		t.Errorf("Normalize() = %+v", metafield)
+	}
+	if err := metafield.Validate(); err != nil {
+		t.Errorf("Validate() after Normalize error = %v", err)
+	}
+}
+
+func TestGetContextNotFound(t *testing.T) {
+	gets := map[string]func(*Client) error{
+		"product": func(c *Client) error {
+			_, err := c.Products.GetContext(t.Context(), 1, nil)
+			return err
+		},
+		"option value": func(c *Client) error {
+			_, err := c.Options.GetOptionValueContext(t.Context(), 1, 2, 3)
+			return err
+		},
+		"modifier value": func(c *Client) error {
+			_, err := c.Modifiers.GetModifierValueContext(t.Context(), 1, 2, 3)
+			return err
+		},
+	}
+	responses := map[string]http.HandlerFunc{
+		"404": func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(t, w, http.StatusNotFound, map[string]interface{}{"status": 404, "title": "Not Found"})
+		},
+		"empty 200": func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{}})
+		},
+	}
+	for name, get := range gets {
+		for response, handler := range responses {
+			t.Run(name+" "+response, func(t *testing.T) {
+				if err := get(newTestClient(t, handler)); !IsNotFound(err) {
+					t.Errorf("error = %v, want not found", err)
+				}
+			})
+		}
+	}
+}
+
+func TestRequestTimeoutYieldsToContextDeadline(t *testing.T) {
+	// slowHandler answers after delay unless the request is cancelled first
+	slowHandler := func(delay time.Duration) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(delay):
+				writeJSON(t, w, http.StatusOK, ProductResponse{Data: Product{ID: 1}})
+			}
+		})
+	}
+
+	tests := []struct {
+		name           string
+		requestTimeout time.Duration
+		ctxTimeout     time.Duration
+		delay          time.Duration
+		wantTimeout    bool
+	}{
+		{"shorter context deadline wins", 5 * time.Second, 50 * time.Millisecond, 2 * time.Second, true},
+		{"longer context deadline wins", 20 * time.Millisecond, 2 * time.Second, 100 * time.Millisecond, false},
+		{"request timeout without a context deadline", 50 * time.Millisecond, 0, 2 * time.Second, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, slowHandler(tt.delay), WithRequestTimeout(tt.requestTimeout))
+
+			ctx := t.Context()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			_, err := client.Products.GetContext(ctx, 1, nil)
+			elapsed := time.Since(start)
+
+			if got := errors.Is(err, context.DeadlineExceeded); got != tt.wantTimeout {
+				t.Fatalf("GetContext() error = %v, want a deadline: %v", err, tt.wantTimeout)
+			}
+			if tt.wantTimeout && elapsed >= time.Second {
+				t.Errorf("GetContext() took %s to time out", elapsed)
+			}
+		})
+	}
+
+	if client := NewClient("store", "token"); client.timeout != defaultRequestTimeout {
+		t.Errorf("NewClient() request timeout = %s, want %s", client.timeout, defaultRequestTimeout)
+	}
+}
+
+func TestNestedConfigsAreOmittedWhenUnset(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		key     string
+		present bool
+	}{
+		{"minimal product", Product{Name: "Mug", Type: ProductTypePhysical}, "options", false},
+		{"option without config", ProductOption{DisplayName: "Size", Type: "dropdown"}, "config", false},
+		{"option with config", ProductOption{DisplayName: "Size", Type: "dropdown", Config: &OptionConfig{DefaultValue: "M"}}, "config", true},
+		{"modifier without config", Modifier{Name: "Gift Box", DisplayName: "Gift Box", Type: "dropdown"}, "config", false},
+		{"modifier with config", Modifier{Name: "Engraving", DisplayName: "Engraving", Type: "text", Config: &ModifierConfig{TextMaxLength: 20}}, "config", true},
+		{"rule without adjusters", ComplexRule{Enabled: true, Purchasing: true}, "price_adjuster", false},
+		{"rule with adjusters", ComplexRule{Enabled: true, Adjusters: &RuleAdjusters{Type: "relative", Amount: 5}}, "price_adjuster", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(encoded), "{}") {
+				t.Errorf("Marshal() = %s, has an empty object", encoded)
+			}
+
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(encoded, &fields); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := fields[tt.key]; ok != tt.present {
+				t.Errorf("Marshal() = %s, want %s present: %v", encoded, tt.key, tt.present)
+			}
+
+			// Decoding back gives the same value, pointers included
+			decoded := reflect.New(reflect.TypeOf(tt.value))
+			if err := json.Unmarshal(encoded, decoded.Interface()); err != nil {
+				t.Fatal(err)
+			}
+			if got := decoded.Elem().Interface(); !reflect.DeepEqual(got, tt.value) {
+				t.Errorf("round trip = %+v, want %+v", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestResponsesParsePagination(t *testing.T) {
+	const body = `{"data": null, "meta": {"pagination": {"total": 95, "count": 50, "per_page": 50, "current_page": 1, "total_pages": 2, "links": {"current": "?page=1&limit=50", "next": "?page=2&limit=50"}}}}`
+
+	responses := []Paginated{
+		new(ProductResponse),
+		new(ProductsResponse),
+		new(ProductImageResponse),
+		new(ProductImagesResponse),
+		new(ProductVideoResponse),
+		new(ProductVideosResponse),
+		new(CategoryResponse),
+		new(CategoriesResponse),
+		new(BrandResponse),
+		new(BrandsResponse),
+		new(VariantResponse),
+		new(VariantsResponse),
+		new(OptionValueResponse),
+		new(OptionValuesResponse),
+		new(ProductOptionResponse),
+		new(ProductOptionsResponse),
+		new(ModifierResponse),
+		new(ModifiersResponse),
+		new(ReviewResponse),
+		new(ReviewsResponse),
+		new(ComplexRuleResponse),
+		new(ComplexRulesResponse),
+		new(CustomFieldResponse),
+		new(CustomFieldsResponse),
+		new(PricingRuleResponse),
+		new(PricingRulesResponse),
+		new(ChannelResponse),
+		new(ChannelsResponse),
+		new(MetafieldResponse),
+		new(MetafieldsResponse),
+		new(SummaryResponse),
+		new(ProductSortOrdersResponse),
+		new(ProductChannelAssignmentsResponse),
+		new(CategoryAssignmentsResponse),
+		new(BatchErrorResponse),
+		new(BatchProductsResponse),
+		new(PricingResponse),
+		new(ProductInventoryResponse),
+		new(ProductInventoriesResponse),
+		new(BulkPricingRuleResponse),
+		new(WebhookResponse),
+		new(WebhooksResponse),
+		new(CartResponse),
+		new(AbandonedCartResponse),
+		new(PriceListRecordsResponse),
+		new(CustomerAttributesResponse),
+		new(CustomerAttributeValuesResponse),
+	}
+	for _, response := range responses {
+		t.Run(fmt.Sprintf("%T", response), func(t *testing.T) {
+			if err := json.Unmarshal([]byte(body), response); err != nil {
+				t.Fatal(err)
+			}
+
+			pagination := response.GetPagination().Pagination
+			if pagination.Total != 95 || pagination.Count != 50 || pagination.PerPage != 50 ||
+				pagination.CurrentPage != 1 || pagination.TotalPages != 2 || pagination.Links.Next != "?page=2&limit=50" {
+				t.Errorf("GetPagination() = %+v", pagination)
+			}
+		})
+	}
+}
+
+func TestRouteTemplate(t *testing.T) {
+	client := NewClient("store", "token")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/stores/store/v3/catalog/products", "v3/catalog/products"},
+		{"/stores/store/v3/catalog/products/112/variants/7", "v3/catalog/products/{id}/variants/{id}"},
+		{"/stores/store/v3/carts/3f6a0c8e-5b8f-4c1e-9d2a-7b1e2f3a4b5c", "v3/carts/{id}"},
+		{"/stores/store/v3/abandoned-carts/c2f0a1b9d8e7", "v3/abandoned-carts/{id}"},
+		{"/stores/store/v2/tax_classes", "v2/tax_classes"},
+	}
+	for _, tt := range tests {
+		if got := client.routeTemplate(tt.path); got != tt.want {
+			t.Errorf("routeTemplate(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDeleteByFilterContextRefusesWithoutFilter(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	visible := true
+	client.Products.SetDefaults(&QueryParams{IsVisible: &visible})
+	ctx := WithChannelID(t.Context(), 2)
+
+	for _, params := range []*QueryParams{nil, {Limit: 50, Sort: "name"}} {
+		if _, err := client.Products.DeleteByFilterContext(ctx, params); err == nil {
+			t.Errorf("DeleteByFilterContext(%+v) error = nil, want a refusal", params)
+		}
+	}
+}
+
+func TestProductReadOnlyFields(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		totalSold        int
+		dateLastImported string
+	}{
+		{"present", `{"id": 1, "name": "Mug", "total_sold": 12, "date_last_imported": "2024-01-01T03:04:05+00:00"}`, 12, "2024-01-01T03:04:05+00:00"},
+		{"absent", `{"id": 1, "name": "Mug"}`, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var product Product
+			if err := json.Unmarshal([]byte(tt.body), &product); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if product.ID != 1 || product.Name != "Mug" || product.TotalSold != tt.totalSold || product.DateLastImported != tt.dateLastImported {
+				t.Errorf("Unmarshal() = %+v", product)
+			}
+
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var sent map[string]interface{}
+				if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+					t.Fatal(err)
+				}
+				for _, key := range []string{"total_sold", "date_last_imported"} {
+					if _, ok := sent[key]; ok {
+						t.Errorf("%s sent %s = %v", r.Method, key, sent[key])
+					}
+				}
+				writeJSON(t, w, http.StatusOK, ProductResponse{Data: product})
+			}))
+
+			if _, err := client.Products.UpdateContext(t.Context(), product.ID, &product); err != nil {
+				t.Fatalf("UpdateContext() error = %v", err)
+			}
+			if _, err := client.Products.CreateContext(t.Context(), &product); err != nil {
+				t.Fatalf("CreateContext() error = %v", err)
+			}
+			if product.TotalSold != tt.totalSold {
+				t.Errorf("writing changed TotalSold to %d", product.TotalSold)
+			}
+		})
+	}
+}
+
+func TestDeleteByFilterContextDeletesByIDFilter(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		deleted []int
+		deletes int
+	)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			data := make([]Product, 60)
+			for i := range data {
+				data[i] = Product{ID: i + 1, Name: "Mug"}
+			}
+			writeJSON(t, w, http.StatusOK, ProductsResponse{Data: data, Meta: pageMeta(1, 1)})
+		case http.MethodDelete:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(body) > 0 {
+				t.Errorf("DELETE has body %s", body)
+			}
+			ids := r.URL.Query()["id:in"]
+			if len(ids) == 0 || len(ids) > deleteIDChunkSize {
+				t.Errorf("DELETE filters by id:in %v, want 1 to %d IDs", ids, deleteIDChunkSize)
+			}
+
+			mu.Lock()
+			deletes++
+			for _, id := range ids {
+				productID, _ := strconv.Atoi(id)
+				deleted = append(deleted, productID)
+			}
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	result, err := client.Products.DeleteByFilterContext(t.Context(), &QueryParams{Keywords: "mug"})
+	if err != nil {
+		t.Fatalf("DeleteByFilterContext() error = %v", err)
+	}
+	if len(result.Deleted) != 60 || len(deleted) != 60 || deletes != 2 {
+		t.Errorf("deleted %d products (%d reported) in %d requests, want 60 in 2", len(deleted), len(result.Deleted), deletes)
+	}
+}
+
+func TestRetryLeavesUnsafePOSTsAlone(t *testing.T) {
+	tests := []struct {
+		method   string
+		status   int
+		attempts int
+	}{
+		{http.MethodGet, http.StatusServiceUnavailable, 3},
+		{http.MethodPut, http.StatusGatewayTimeout, 3},
+		{http.MethodDelete, http.StatusBadGateway, 3},
+		{http.MethodPost, http.StatusTooManyRequests, 3},
+		{http.MethodPost, http.StatusServiceUnavailable, 1},
+		{http.MethodPost, http.StatusGatewayTimeout, 1},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d", tt.method, tt.status), func(t *testing.T) {
+			var attempts int
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.Header().Set("X-Rate-Limit-Time-Reset-Ms", "1")
+				writeJSON(t, w, tt.status, map[string]interface{}{"status": tt.status})
+			}), WithRetry(2))
+			client.backoff = &backoff{Base: time.Millisecond, Max: time.Millisecond}
+
+			req, err := client.NewRequest(t.Context(), tt.method, "catalog/products", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.Do(req, nil); err == nil {
+				t.Error("Do() error = nil")
+			}
+			if attempts != tt.attempts {
+				t.Errorf("sent %d attempts, want %d", attempts, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryableLostResponses(t *testing.T) {
+	refused := &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}
+	lost := &url.Error{Op: "Post", URL: "https://example.com", Err: io.ErrUnexpectedEOF}
+
+	tests := []struct {
+		method string
+		err    error
+		want   bool
+	}{
+		{http.MethodGet, lost, true},
+		{http.MethodPost, lost, false},
+		{http.MethodPost, refused, true},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "https://example.com", nil)
+		if got := retryable(req, nil, tt.err); got != tt.want {
+			t.Errorf("retryable(%s, %v) = %v, want %v", tt.method, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestEffectivePrice(t *testing.T) {
+	tiers := []PricingRule{
+		{QuantityMin: 5, QuantityMax: 9, Type: "price", Amount: 2},
+		{QuantityMin: 10, Type: "percent", Amount: 25},
+		{QuantityMin: 20, QuantityMax: 49, Type: "fixed", Amount: 6},
+	}
+
+	tests := []struct {
+		name     string
+		base     float64
+		rules    []PricingRule
+		quantity int
+		want     float64
+	}{
+		{"price", 10, tiers, 5, 8},
+		{"percent", 10, tiers, 10, 7.5},
+		{"fixed", 10, tiers, 20, 6},
+		{"unbounded maximum", 10, tiers, 1000, 7.5},
+		{"highest minimum wins", 10, tiers, 30, 6},
+		{"no matching tier", 10, tiers, 4, 10},
+		{"no rules", 10, nil, 5, 10},
+		{"clamped at zero", 10, []PricingRule{{QuantityMin: 1, Type: "price", Amount: 15}}, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EffectivePrice(tt.base, tt.rules, tt.quantity); got != tt.want {
+				t.Errorf("EffectivePrice(%.2f, %d) = %.2f, want %.2f", tt.base, tt.quantity, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateRatings(t *testing.T) {
+	reviews := []Review{
+		{Rating: 5, Status: ReviewStatusApproved},
+		{Rating: 4, Status: ReviewStatusApproved},
+		{Rating: 5, Status: ReviewStatusApproved},
+		{Rating: 1, Status: "pending"},
+		{Rating: 2, Status: "disapproved"},
+	}
+
+	tests := []struct {
+		name          string
+		aggregate     func([]Review) (float64, int, map[int]int)
+		reviews       []Review
+		wantAvg       float64
+		wantCount     int
+		wantHistogram map[int]int
+	}{
+		{"approved only", AggregateRatings, reviews, 14.0 / 3, 3, map[int]int{4: 1, 5: 2}},
+		{"all statuses", AggregateAllRatings, reviews, 17.0 / 5, 5, map[int]int{1: 1, 2: 1, 4: 1, 5: 2}},
+		{"none approved", AggregateRatings, reviews[3:], 0, 0, map[int]int{}},
+		{"empty", AggregateRatings, nil, 0, 0, map[int]int{}},
+		{"empty with all statuses", AggregateAllRatings, nil, 0, 0, map[int]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			avg, count, histogram := tt.aggregate(tt.reviews)
+			if avg != tt.wantAvg || count != tt.wantCount {
+				t.Errorf("avg, count = %v, %d, want %v, %d", avg, count, tt.wantAvg, tt.wantCount)
+			}
+			if !reflect.DeepEqual(histogram, tt.wantHistogram) {
+				t.Errorf("histogram = %v, want %v", histogram, tt.wantHistogram)
+			}
+		})
+	}
+}
+
+func TestProductNormalizePrices(t *testing.T) {
+	tests := []struct {
+		name    string
+		product Product
+		want    Product
+	}{
+		{"rounded to cents", Product{Price: 10.005, CostPrice: 4.444}, Product{Price: 10.01, CostPrice: 4.44}},
+		{"cost above price", Product{Price: 10, CostPrice: 12}, Product{Price: 10, CostPrice: 10}},
+		{"retail below price", Product{Price: 10, RetailPrice: 8}, Product{Price: 10, RetailPrice: 10}},
+		{"sale above price", Product{Price: 10, SalePrice: 11}, Product{Price: 10, SalePrice: 10}},
+		{"sale below map", Product{Price: 10, SalePrice: 7, MapPrice: 8}, Product{Price: 10, SalePrice: 8, MapPrice: 8}},
+		{"map above price kept", Product{Price: 10, MapPrice: 12}, Product{Price: 10, MapPrice: 12}},
+		{"map above price drops sale", Product{Price: 10, SalePrice: 9, MapPrice: 12}, Product{Price: 10, MapPrice: 12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := tt.product
+			product.NormalizePrices()
+			if !reflect.DeepEqual(product, tt.want) {
+				t.Errorf("NormalizePrices() = %+v, want %+v", product, tt.want)
+			}
+		})
+	}
+}
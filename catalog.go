@@ -0,0 +1,684 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// CatalogSnapshot is a golden copy of a store's catalog. Products are keyed
+// by SKU and brands and categories by name.
+type CatalogSnapshot struct {
+	Categories []Category `json:"categories"`
+	Brands     []Brand    `json:"brands"`
+	Products   []Product  `json:"products"`
+}
+
+func LoadCatalogSnapshot(path string) (*CatalogSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := new(CatalogSnapshot)
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode catalog snapshot %s: %w", path, err)
+	}
+
+	return snapshot, nil
+}
+
+// snapshotProductIncludes are the sub-resources written with each product
+// of a snapshot.
+var snapshotProductIncludes = []string{"variants", "images", "videos", "custom_fields", "bulk_pricing_rules", "options", "modifiers"}
+
+// WriteCatalogSnapshot writes the store's catalog to w in the format
+// LoadCatalogSnapshot reads. Products, with their sub-resources, are
+// written as each page is streamed rather than collected first, so memory
+// stays flat however large the catalog; categories and brands are listed
+// up front. On error w holds a truncated document.
+func WriteCatalogSnapshot(ctx context.Context, client *Client, w io.Writer) error {
+	categories, err := client.Categories.ListAllContext(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list categories: %w", err)
+	}
+	brands, err := client.Brands.ListAllContext(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list brands: %w", err)
+	}
+
+	sw := &snapshotWriter{w: w}
+	sw.write(`{"categories":`)
+	sw.writeJSON(nonNil(categories))
+	sw.write(`,"brands":`)
+	sw.writeJSON(nonNil(brands))
+	sw.write(`,"products":[`)
+	if sw.err != nil {
+		return sw.err
+	}
+
+	first := true
+	err = client.Products.StreamContext(ctx, &QueryParams{Include: snapshotProductIncludes}, func(product Product) error {
+		if !first {
+			sw.write(",")
+		}
+		first = false
+		sw.writeJSON(product)
+		return sw.err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream products: %w", err)
+	}
+
+	sw.write("]}")
+	return sw.err
+}
+
+// snapshotWriter keeps the first write error so a document can be written
+// piecewise and checked once.
+type snapshotWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (sw *snapshotWriter) write(s string) {
+	if sw.err == nil {
+		_, sw.err = io.WriteString(sw.w, s)
+	}
+}
+
+func (sw *snapshotWriter) writeJSON(v interface{}) {
+	if sw.err != nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		sw.err = err
+		return
+	}
+	_, sw.err = sw.w.Write(data)
+}
+
+// nonNil keeps an empty listing an empty array rather than null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// CatalogDiff describes the live store relative to a snapshot: Added is in
+// the store but not the snapshot, Removed is in the snapshot but not the
+// store.
+type CatalogDiff struct {
+	AddedProducts   []Product     `json:"added_products,omitempty"`
+	RemovedProducts []Product     `json:"removed_products,omitempty"`
+	ChangedProducts []ProductDiff `json:"changed_products,omitempty"`
+
+	AddedBrands   []string `json:"added_brands,omitempty"`
+	RemovedBrands []string `json:"removed_brands,omitempty"`
+
+	AddedCategories   []string `json:"added_categories,omitempty"`
+	RemovedCategories []string `json:"removed_categories,omitempty"`
+}
+
+type ProductDiff struct {
+	ID     int         `json:"id"`
+	SKU    string      `json:"sku"`
+	Fields []FieldDiff `json:"fields"`
+}
+
+type FieldDiff struct {
+	Field    string      `json:"field"`
+	Snapshot interface{} `json:"snapshot"`
+	Live     interface{} `json:"live"`
+}
+
+func (d *CatalogDiff) Empty() bool {
+	return len(d.AddedProducts) == 0 && len(d.RemovedProducts) == 0 && len(d.ChangedProducts) == 0 &&
+		len(d.AddedBrands) == 0 && len(d.RemovedBrands) == 0 &&
+		len(d.AddedCategories) == 0 && len(d.RemovedCategories) == 0
+}
+
+// productDiffIgnored are fields the store assigns, that refer to other
+// resources by store-specific ID (brand and categories are compared by name
+// through productRefs), or that are compared through their own endpoints
+// rather than on the product itself.
+var productDiffIgnored = map[string]bool{
+	"id":                 true,
+	"date_created":       true,
+	"date_modified":      true,
+	"view_count":         true,
+	"base_variant_id":    true,
+	"brand_id":           true,
+	"categories":         true,
+	"tax_class_id":       true,
+	"related_products":   true,
+	"images":             true,
+	"videos":             true,
+	"custom_fields":      true,
+	"bulk_pricing_rules": true,
+	"variants":           true,
+	"options":            true,
+	"modifiers":          true,
+	"reviews":            true,
+	"complex_rules":      true,
+}
+
+// DiffCatalog compares the live store against snapshot without modifying
+// anything. Only fields set in the snapshot are compared, so a sparse
+// snapshot checks just what it specifies.
+func DiffCatalog(ctx context.Context, client *Client, snapshot *CatalogSnapshot) (*CatalogDiff, error) {
+	diff := new(CatalogDiff)
+
+	liveProducts, err := client.Products.ListAllContext(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+	liveBrands, err := client.Brands.ListAllContext(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list brands: %w", err)
+	}
+	liveCategories, err := client.Categories.ListAllContext(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	// Brand and category IDs differ between stores, so products are
+	// compared by the names they refer to, as SyncCatalog maps them
+	refs := productRefs{
+		snapshotBrands:     brandNames(snapshot.Brands),
+		liveBrands:         brandNames(liveBrands),
+		snapshotCategories: categoryNames(snapshot.Categories),
+		liveCategories:     categoryNames(liveCategories),
+	}
+
+	liveBySKU := make(map[string]Product, len(liveProducts))
+	for _, product := range liveProducts {
+		liveBySKU[product.SKU] = product
+	}
+
+	snapshotSKUs := make(map[string]bool, len(snapshot.Products))
+	for _, expected := range snapshot.Products {
+		snapshotSKUs[expected.SKU] = true
+
+		live, ok := liveBySKU[expected.SKU]
+		if !ok {
+			diff.RemovedProducts = append(diff.RemovedProducts, expected)
+			continue
+		}
+
+		fields, err := diffProductFields(&expected, &live)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, refs.diff(&expected, &live)...)
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+		if len(fields) > 0 {
+			diff.ChangedProducts = append(diff.ChangedProducts, ProductDiff{ID: live.ID, SKU: live.SKU, Fields: fields})
+		}
+	}
+
+	for _, live := range liveProducts {
+		if !snapshotSKUs[live.SKU] {
+			diff.AddedProducts = append(diff.AddedProducts, live)
+		}
+	}
+
+	liveBrandNames := make([]string, 0, len(liveBrands))
+	for _, brand := range liveBrands {
+		liveBrandNames = append(liveBrandNames, brand.Name)
+	}
+	snapshotBrandNames := make([]string, 0, len(snapshot.Brands))
+	for _, brand := range snapshot.Brands {
+		snapshotBrandNames = append(snapshotBrandNames, brand.Name)
+	}
+	diff.AddedBrands, diff.RemovedBrands = diffNames(snapshotBrandNames, liveBrandNames)
+
+	liveCategoryNames := make([]string, 0, len(liveCategories))
+	for _, category := range liveCategories {
+		liveCategoryNames = append(liveCategoryNames, category.Name)
+	}
+	snapshotCategoryNames := make([]string, 0, len(snapshot.Categories))
+	for _, category := range snapshot.Categories {
+		snapshotCategoryNames = append(snapshotCategoryNames, category.Name)
+	}
+	diff.AddedCategories, diff.RemovedCategories = diffNames(snapshotCategoryNames, liveCategoryNames)
+
+	return diff, nil
+}
+
+// productRefs resolves the brand and category IDs of snapshot and live
+// products to names.
+type productRefs struct {
+	snapshotBrands, liveBrands         map[int]string
+	snapshotCategories, liveCategories map[int]string
+}
+
+// diff reports the brand and categories of expected that name something
+// other than live's. Like the other fields, they are only compared when set
+// in the snapshot.
+func (r productRefs) diff(expected, live *Product) []FieldDiff {
+	var fields []FieldDiff
+
+	if expected.BrandID != 0 {
+		want, got := refName(r.snapshotBrands, expected.BrandID), refName(r.liveBrands, live.BrandID)
+		if live.BrandID == 0 {
+			got = ""
+		}
+		if want != got {
+			fields = append(fields, FieldDiff{Field: "brand", Snapshot: want, Live: got})
+		}
+	}
+
+	if len(expected.Categories) > 0 {
+		want, got := refNames(r.snapshotCategories, expected.Categories), refNames(r.liveCategories, live.Categories)
+		if !reflect.DeepEqual(want, got) {
+			fields = append(fields, FieldDiff{Field: "categories", Snapshot: want, Live: got})
+		}
+	}
+
+	return fields
+}
+
+func brandNames(brands []Brand) map[int]string {
+	names := make(map[int]string, len(brands))
+	for _, brand := range brands {
+		names[brand.ID] = brand.Name
+	}
+	return names
+}
+
+func categoryNames(categories []Category) map[int]string {
+	names := make(map[int]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+	return names
+}
+
+// refName returns the name of id, or the ID itself when it is not listed.
+func refName(names map[int]string, id int) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return "#" + strconv.Itoa(id)
+}
+
+// refNames returns the sorted names of ids, so order does not count as a
+// change.
+func refNames(names map[int]string, ids []int) []string {
+	resolved := make([]string, 0, len(ids))
+	for _, id := range ids {
+		resolved = append(resolved, refName(names, id))
+	}
+	sort.Strings(resolved)
+	return resolved
+}
+
+func diffProductFields(expected, live *Product) ([]FieldDiff, error) {
+	expectedFields, err := jsonFields(expected)
+	if err != nil {
+		return nil, err
+	}
+	liveFields, err := jsonFields(live)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(expectedFields))
+	for name := range expectedFields {
+		if !productDiffIgnored[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var fields []FieldDiff
+	for _, name := range names {
+		if !reflect.DeepEqual(expectedFields[name], liveFields[name]) {
+			fields = append(fields, FieldDiff{Field: name, Snapshot: expectedFields[name], Live: liveFields[name]})
+		}
+	}
+
+	return fields, nil
+}
+
+// jsonFields flattens v to its top-level JSON fields so values are compared
+// exactly as they would be sent to the API.
+func jsonFields(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func diffNames(snapshot, live []string) (added, removed []string) {
+	inSnapshot := make(map[string]bool, len(snapshot))
+	for _, name := range snapshot {
+		inSnapshot[name] = true
+	}
+	inLive := make(map[string]bool, len(live))
+	for _, name := range live {
+		inLive[name] = true
+		if !inSnapshot[name] {
+			added = append(added, name)
+		}
+	}
+	for _, name := range snapshot {
+		if !inLive[name] {
+			removed = append(removed, name)
+		}
+	}
+	return added, removed
+}
+
+type SyncOptions struct {
+	// DeleteMissing removes live products, brands and categories that are
+	// not in the snapshot.
+	DeleteMissing bool
+
+	// DryRun reports the planned changes without making any of them.
+	DryRun bool
+}
+
+const (
+	SyncActionCreate = "create"
+	SyncActionUpdate = "update"
+	SyncActionDelete = "delete"
+)
+
+type SyncAction struct {
+	Entity string `json:"entity"`
+	Key    string `json:"key"`
+	Action string `json:"action"`
+	ID     int    `json:"id,omitempty"`
+}
+
+type SyncReport struct {
+	DryRun  bool         `json:"dry_run"`
+	Actions []SyncAction `json:"actions"`
+}
+
+func (r *SyncReport) add(entity, key, action string, id int) {
+	r.Actions = append(r.Actions, SyncAction{Entity: entity, Key: key, Action: action, ID: id})
+}
+
+// SyncCatalog makes the live store match snapshot, matching products by SKU
+// and brands and categories by name. Brand, category and parent IDs in the
+// snapshot are remapped to the live store's IDs. The report lists every
+// change made, or every change planned when opts.DryRun is set.
+func SyncCatalog(ctx context.Context, client *Client, snapshot *CatalogSnapshot, opts SyncOptions) (*SyncReport, error) {
+	report := &SyncReport{DryRun: opts.DryRun}
+
+	liveBrands, err := client.Brands.ListAllContext(ctx, nil)
+	if err != nil {
+		return report, fmt.Errorf("failed to list brands: %w", err)
+	}
+	liveCategories, err := client.Categories.ListAllContext(ctx, nil)
+	if err != nil {
+		return report, fmt.Errorf("failed to list categories: %w", err)
+	}
+	liveProducts, err := client.Products.ListAllContext(ctx, nil)
+	if err != nil {
+		return report, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	brandIDs, err := syncBrands(ctx, client, snapshot.Brands, liveBrands, opts, report)
+	if err != nil {
+		return report, err
+	}
+	categoryIDs, err := syncCategories(ctx, client, snapshot.Categories, liveCategories, opts, report)
+	if err != nil {
+		return report, err
+	}
+
+	liveBySKU := make(map[string]Product, len(liveProducts))
+	for _, product := range liveProducts {
+		liveBySKU[product.SKU] = product
+	}
+
+	snapshotSKUs := make(map[string]bool, len(snapshot.Products))
+	for _, expected := range snapshot.Products {
+		snapshotSKUs[expected.SKU] = true
+
+		product := expected
+		product.ID = 0
+		clearSubResources(&product)
+		if id, ok := brandIDs[product.BrandID]; ok {
+			product.BrandID = id
+		}
+		product.Categories = make([]int, 0, len(expected.Categories))
+		for _, categoryID := range expected.Categories {
+			if id, ok := categoryIDs[categoryID]; ok {
+				categoryID = id
+			}
+			product.Categories = append(product.Categories, categoryID)
+		}
+
+		live, exists := liveBySKU[product.SKU]
+		if exists {
+			fields, err := diffProductFields(&product, &live)
+			if err != nil {
+				return report, err
+			}
+			if len(fields) == 0 && sameInts(product.Categories, live.Categories) && product.BrandID == live.BrandID {
+				continue
+			}
+		}
+
+		if opts.DryRun {
+			if exists {
+				report.add("product", product.SKU, SyncActionUpdate, live.ID)
+			} else {
+				report.add("product", product.SKU, SyncActionCreate, 0)
+			}
+			continue
+		}
+
+		response, created, err := client.Products.UpsertBySKUContext(ctx, &product)
+		if err != nil {
+			return report, fmt.Errorf("failed to upsert product %s: %w", product.SKU, err)
+		}
+		if created {
+			report.add("product", product.SKU, SyncActionCreate, response.Data.ID)
+		} else {
+			report.add("product", product.SKU, SyncActionUpdate, response.Data.ID)
+		}
+	}
+
+	if opts.DeleteMissing {
+		for _, live := range liveProducts {
+			if snapshotSKUs[live.SKU] {
+				continue
+			}
+			if !opts.DryRun {
+				if err := client.Products.DeleteContext(ctx, live.ID); err != nil {
+					return report, fmt.Errorf("failed to delete product %s: %w", live.SKU, err)
+				}
+			}
+			report.add("product", live.SKU, SyncActionDelete, live.ID)
+		}
+	}
+
+	return report, nil
+}
+
+// clearSubResources drops the sub-resources a snapshot carries with each
+// product. They hold the source store's IDs, and options and modifiers
+// cannot be written on the product, so sync leaves them to their own
+// endpoints, as productDiffIgnored does when diffing.
+func clearSubResources(product *Product) {
+	product.Images = nil
+	product.Videos = nil
+	product.CustomFields = nil
+	product.BulkPricingRules = nil
+	product.Variants = nil
+	product.Options = nil
+	product.Modifiers = nil
+	product.Reviews = nil
+	product.ComplexRules = nil
+}
+
+// syncBrands returns a map from snapshot brand IDs to live brand IDs.
+func syncBrands(ctx context.Context, client *Client, brands, liveBrands []Brand, opts SyncOptions, report *SyncReport) (map[int]int, error) {
+	liveByName := make(map[string]int, len(liveBrands))
+	for _, brand := range liveBrands {
+		liveByName[brand.Name] = brand.ID
+	}
+
+	brandIDs := make(map[int]int, len(brands))
+	inSnapshot := make(map[string]bool, len(brands))
+	for _, expected := range brands {
+		inSnapshot[expected.Name] = true
+
+		if id, ok := liveByName[expected.Name]; ok {
+			brandIDs[expected.ID] = id
+			continue
+		}
+
+		if opts.DryRun {
+			report.add("brand", expected.Name, SyncActionCreate, 0)
+			continue
+		}
+
+		brand := expected
+		brand.ID = 0
+		response, created, err := client.Brands.FindOrCreateContext(ctx, &brand)
+		if err != nil {
+			return brandIDs, fmt.Errorf("failed to create brand %s: %w", brand.Name, err)
+		}
+		brandIDs[expected.ID] = response.Data.ID
+		if created {
+			report.add("brand", brand.Name, SyncActionCreate, response.Data.ID)
+		}
+	}
+
+	if opts.DeleteMissing {
+		for _, live := range liveBrands {
+			if inSnapshot[live.Name] {
+				continue
+			}
+			if !opts.DryRun {
+				if err := client.Brands.DeleteContext(ctx, live.ID); err != nil {
+					return brandIDs, fmt.Errorf("failed to delete brand %s: %w", live.Name, err)
+				}
+			}
+			report.add("brand", live.Name, SyncActionDelete, live.ID)
+		}
+	}
+
+	return brandIDs, nil
+}
+
+// syncCategories returns a map from snapshot category IDs to live category
+// IDs. Parents are always handled before their children so parent IDs can
+// be remapped. On a dry run, categories that would be created map to
+// distinct negative IDs, so their children never match a live category.
+func syncCategories(ctx context.Context, client *Client, categories, liveCategories []Category, opts SyncOptions, report *SyncReport) (map[int]int, error) {
+	type liveKey struct {
+		name     string
+		parentID int
+	}
+	liveByKey := make(map[liveKey]int, len(liveCategories))
+	for _, category := range liveCategories {
+		liveByKey[liveKey{category.Name, category.ParentID}] = category.ID
+	}
+
+	categoryIDs := make(map[int]int, len(categories))
+	kept := make(map[int]bool, len(categories))
+	remaining := append([]Category(nil), categories...)
+	planned := 0
+
+	for len(remaining) > 0 {
+		var deferred []Category
+
+		for _, expected := range remaining {
+			parentID := expected.ParentID
+			if parentID != 0 {
+				mapped, ok := categoryIDs[parentID]
+				if !ok {
+					deferred = append(deferred, expected)
+					continue
+				}
+				parentID = mapped
+			}
+
+			if id, ok := liveByKey[liveKey{expected.Name, parentID}]; ok {
+				categoryIDs[expected.ID] = id
+				kept[id] = true
+				continue
+			}
+
+			if opts.DryRun {
+				report.add("category", expected.Name, SyncActionCreate, 0)
+				planned--
+				categoryIDs[expected.ID] = planned
+				continue
+			}
+
+			category := expected
+			category.ID = 0
+			category.ParentID = parentID
+			response, created, err := client.Categories.FindOrCreateContext(ctx, &category)
+			if err != nil {
+				return categoryIDs, fmt.Errorf("failed to create category %s: %w", category.Name, err)
+			}
+			categoryIDs[expected.ID] = response.Data.ID
+			kept[response.Data.ID] = true
+			if created {
+				report.add("category", category.Name, SyncActionCreate, response.Data.ID)
+			}
+		}
+
+		if len(deferred) == len(remaining) {
+			return categoryIDs, fmt.Errorf("snapshot categories reference %d missing parents", len(deferred))
+		}
+		remaining = deferred
+	}
+
+	if opts.DeleteMissing {
+		for _, live := range liveCategories {
+			if kept[live.ID] {
+				continue
+			}
+			if !opts.DryRun {
+				if err := client.Categories.DeleteContext(ctx, live.ID); err != nil {
+					return categoryIDs, fmt.Errorf("failed to delete category %s: %w", live.Name, err)
+				}
+			}
+			report.add("category", live.Name, SyncActionDelete, live.ID)
+		}
+	}
+
+	return categoryIDs, nil
+}
+
+func sameInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[int]int, len(a))
+	for _, v := range a {
+		counts[v]++
+	}
+	for _, v := range b {
+		counts[v]--
+		if counts[v] < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSyncCategoriesDryRunDoesNotMatchUnderPlannedParents(t *testing.T) {
+	live := []Category{{ID: 7, Name: "Shoes"}, {ID: 8, Name: "Outdoor"}}
+	snapshot := []Category{
+		{ID: 1, Name: "Footwear"},
+		{ID: 2, Name: "Shoes", ParentID: 1},
+		{ID: 3, Name: "Boots", ParentID: 1},
+		{ID: 4, Name: "Outdoor"},
+	}
+
+	report := &SyncReport{DryRun: true}
+	categoryIDs, err := syncCategories(t.Context(), nil, snapshot, live, SyncOptions{DryRun: true}, report)
+	if err != nil {
+		t.Fatalf("syncCategories() error = %v", err)
+	}
+
+	if categoryIDs[4] != 8 {
+		t.Errorf("Outdoor mapped to %d, want the live 8", categoryIDs[4])
+	}
+	if categoryIDs[2] == 7 {
+		t.Error("Shoes under a planned parent matched the live root Shoes")
+	}
+
+	placeholders := make(map[int]bool)
+	for _, id := range []int{1, 2, 3} {
+		if categoryIDs[id] >= 0 || placeholders[categoryIDs[id]] {
+			t.Errorf("planned category %d mapped to %d, want a distinct negative placeholder", id, categoryIDs[id])
+		}
+		placeholders[categoryIDs[id]] = true
+	}
+
+	var created []string
+	for _, action := range report.Actions {
+		if action.Action == SyncActionCreate {
+			created = append(created, action.Key)
+		}
+	}
+	if len(created) != 3 {
+		t.Errorf("planned creates %v, want Footwear, Shoes and Boots", created)
+	}
+}
+
+func TestSyncCatalogFromExportedSnapshotSendsNoSubResources(t *testing.T) {
+	withSubResources := func(product Product) Product {
+		product.Images = []ProductImage{{ID: 501, ProductID: product.ID, ImageURL: "https://example.com/a.jpg"}}
+		product.Videos = []ProductVideo{{ID: 502, ProductID: product.ID, VideoID: "abc"}}
+		product.CustomFields = []CustomField{{ID: 503, Name: "Material", Value: "Steel"}}
+		product.BulkPricingRules = []PricingRule{{ID: 504, QuantityMin: 5, Type: "percent", Amount: 10}}
+		product.Variants = []Variant{{ID: 505, ProductID: product.ID, SKU: product.SKU + "-1"}}
+		product.Options = []ProductOption{{ID: 506, ProductID: product.ID, DisplayName: "Size", Type: "dropdown"}}
+		product.Modifiers = []Modifier{{ID: 507, ProductID: product.ID, Name: "Engraving", DisplayName: "Engraving", Type: "text"}}
+		return product
+	}
+
+	var (
+		mu       sync.Mutex
+		products = []Product{
+			{ID: 11, Name: "Mug", Type: ProductTypePhysical, SKU: "MUG-1", Price: 10},
+			{ID: 12, Name: "Cup", Type: ProductTypePhysical, SKU: "CUP-1", Price: 8},
+		}
+		writes []map[string]interface{}
+	)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		path := strings.TrimPrefix(r.URL.Path, "/stores/store/v3/")
+		switch {
+		case path == "catalog/categories" || path == "catalog/brands":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"data": []interface{}{}, "meta": pageMeta(1, 1)})
+		case path == "catalog/products" && r.Method == http.MethodGet:
+			sku := r.URL.Query().Get("sku")
+			include := r.URL.Query().Get("include") != ""
+			data := []Product{}
+			for _, product := range products {
+				if sku != "" && product.SKU != sku {
+					continue
+				}
+				if include {
+					product = withSubResources(product)
+				}
+				data = append(data, product)
+			}
+			writeJSON(t, w, http.StatusOK, ProductsResponse{Data: data, Meta: pageMeta(1, 1)})
+		case strings.HasPrefix(path, "catalog/products") && (r.Method == http.MethodPost || r.Method == http.MethodPut):
+			var sent map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Fatal(err)
+			}
+			writes = append(writes, sent)
+			id, _ := strconv.Atoi(strings.TrimPrefix(path, "catalog/products/"))
+			if id == 0 {
+				id = 99
+			}
+			writeJSON(t, w, http.StatusOK, ProductResponse{Data: Product{ID: id, SKU: sent["sku"].(string)}})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	var buf bytes.Buffer
+	if err := WriteCatalogSnapshot(t.Context(), client, &buf); err != nil {
+		t.Fatalf("WriteCatalogSnapshot() error = %v", err)
+	}
+	snapshot := new(CatalogSnapshot)
+	if err := json.Unmarshal(buf.Bytes(), snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Products) != 2 || len(snapshot.Products[0].Variants) == 0 {
+		t.Fatalf("snapshot products = %+v, want both with sub-resources", snapshot.Products)
+	}
+
+	// Change one product and remove the other so syncing back updates one
+	// and creates one.
+	mu.Lock()
+	products = []Product{{ID: 11, Name: "Mug", Type: ProductTypePhysical, SKU: "MUG-1", Price: 12}}
+	mu.Unlock()
+
+	report, err := SyncCatalog(t.Context(), client, snapshot, SyncOptions{})
+	if err != nil {
+		t.Fatalf("SyncCatalog() error = %v", err)
+	}
+	if len(report.Actions) != 2 || len(writes) != 2 {
+		t.Fatalf("SyncCatalog() made %d writes for actions %+v, want an update and a create", len(writes), report.Actions)
+	}
+
+	for _, sent := range writes {
+		for _, key := range []string{"id", "images", "videos", "custom_fields", "bulk_pricing_rules", "variants", "options", "modifiers"} {
+			if _, ok := sent[key]; ok {
+				t.Errorf("sync of %v sent %s = %v", sent["sku"], key, sent[key])
+			}
+		}
+	}
+}
+
+func TestDiffCatalogComparesBrandAndCategoriesByName(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data interface{}
+		switch strings.TrimPrefix(r.URL.Path, "/stores/store/v3/") {
+		case "catalog/brands":
+			data = []Brand{{ID: 50, Name: "Acme"}, {ID: 51, Name: "Globex"}}
+		case "catalog/categories":
+			data = []Category{{ID: 60, Name: "Shoes"}, {ID: 61, Name: "Boots"}}
+		case "catalog/products":
+			data = []Product{
+				{ID: 1, SKU: "MOVED", BrandID: 51, Categories: []int{61}},
+				{ID: 2, SKU: "SAME", BrandID: 50, Categories: []int{61, 60}},
+			}
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"data": data, "meta": pageMeta(1, 1)})
+	}))
+
+	snapshot := &CatalogSnapshot{
+		Brands:     []Brand{{ID: 1, Name: "Acme"}},
+		Categories: []Category{{ID: 10, Name: "Shoes"}, {ID: 11, Name: "Boots"}},
+		Products: []Product{
+			{SKU: "MOVED", BrandID: 1, Categories: []int{10}},
+			{SKU: "SAME", BrandID: 1, Categories: []int{10, 11}},
+		},
+	}
+
+	diff, err := DiffCatalog(t.Context(), client, snapshot)
+	if err != nil {
+		t.Fatalf("DiffCatalog() error = %v", err)
+	}
+	if len(diff.ChangedProducts) != 1 || diff.ChangedProducts[0].SKU != "MOVED" {
+		t.Fatalf("DiffCatalog() changed products = %+v, want only MOVED", diff.ChangedProducts)
+	}
+
+	want := []FieldDiff{
+		{Field: "brand", Snapshot: "Acme", Live: "Globex"},
+		{Field: "categories", Snapshot: []string{"Shoes"}, Live: []string{"Boots"}},
+	}
+	if got := diff.ChangedProducts[0].Fields; !reflect.DeepEqual(got, want) {
+		t.Errorf("MOVED fields = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// checkpointInterval is how many recorded entities may accumulate before
+// the checkpoint is flushed to disk.
+const checkpointInterval = 25
+
+type Checkpoint struct {
+	mu      sync.Mutex
+	path    string
+	pending int
+
+	Seed         int64          `json:"seed"`
+	Categories   map[string]int `json:"categories"`
+	Brands       map[string]int `json:"brands"`
+	GiftWrapping []int          `json:"gift_wrapping"`
+	Channel      int            `json:"channel,omitempty"`
+	Products     map[string]int `json:"products"`
+	Enriched     map[int]bool   `json:"enriched"`
+
+	// Started holds products whose enrichment began, so a resumed run can
+	// tell a product left part way from one never reached.
+	Started map[int]bool `json:"started"`
+}
+
+func NewCheckpoint(path string, seed int64) *Checkpoint {
+	return &Checkpoint{
+		path:       path,
+		Seed:       seed,
+		Categories: make(map[string]int),
+		Brands:     make(map[string]int),
+		Products:   make(map[string]int),
+		Enriched:   make(map[int]bool),
+		Started:    make(map[int]bool),
+	}
+}
+
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := NewCheckpoint(path, 0)
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint %s: %w", path, err)
+	}
+
+	return checkpoint, nil
+}
+
+// categoryKey includes the position because generated category names are
+// not guaranteed to be unique within a run.
+func categoryKey(index int, name string) string {
+	return strconv.Itoa(index) + ":" + name
+}
+
+func (c *Checkpoint) Category(index int, name string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id, ok := c.Categories[categoryKey(index, name)]
+	return id, ok
+}
+
+func (c *Checkpoint) RecordCategory(index int, name string, id int) {
+	c.record(func() { c.Categories[categoryKey(index, name)] = id })
+}
+
+func (c *Checkpoint) Brand(name string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id, ok := c.Brands[name]
+	return id, ok
+}
+
+func (c *Checkpoint) RecordBrand(name string, id int) {
+	c.record(func() { c.Brands[name] = id })
+}
+
+func (c *Checkpoint) GiftWrappingIDs() ([]int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.GiftWrapping, len(c.GiftWrapping) > 0
+}
+
+func (c *Checkpoint) RecordGiftWrapping(ids []int) {
+	c.record(func() { c.GiftWrapping = ids })
+}
+
+func (c *Checkpoint) ChannelID() (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Channel, c.Channel != 0
+}
+
+func (c *Checkpoint) RecordChannel(id int) {
+	c.record(func() { c.Channel = id })
+}
+
+func (c *Checkpoint) Product(sku string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id, ok := c.Products[sku]
+	return id, ok
+}
+
+func (c *Checkpoint) RecordProduct(sku string, id int) {
+	c.record(func() { c.Products[sku] = id })
+}
+
+func (c *Checkpoint) IsEnriched(productID int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Enriched[productID]
+}
+
+func (c *Checkpoint) RecordEnriched(productID int) {
+	c.record(func() { c.Enriched[productID] = true })
+}
+
+// IsPartlyEnriched reports whether enrichment of productID began but did
+// not finish.
+func (c *Checkpoint) IsPartlyEnriched(productID int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Started[productID] && !c.Enriched[productID]
+}
+
+// RecordStarted marks productID's enrichment begun. It is saved at once,
+// as a product whose start was lost would be enriched twice on resume.
+func (c *Checkpoint) RecordStarted(productID int) {
+	c.mu.Lock()
+	c.Started[productID] = true
+	c.mu.Unlock()
+
+	if err := c.Save(); err != nil {
+		log.Printf("Failed to save checkpoint: %v", err)
+	}
+}
+
+func (c *Checkpoint) record(update func()) {
+	c.mu.Lock()
+	update()
+	c.pending++
+	flush := c.pending >= checkpointInterval
+	c.mu.Unlock()
+
+	if flush {
+		if err := c.Save(); err != nil {
+			log.Printf("Failed to save checkpoint: %v", err)
+		}
+	}
+}
+
+// Save writes the checkpoint atomically. It is a no-op when no checkpoint
+// path was configured.
+func (c *Checkpoint) Save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return err
+	}
+
+	c.pending = 0
+	return nil
+}
+
+func openCheckpoint(cfg *Config) (*Checkpoint, error) {
+	if !cfg.Resume {
+		return NewCheckpoint(cfg.CheckpointPath, cfg.Seed), nil
+	}
+
+	if cfg.CheckpointPath == "" {
+		return nil, errors.New("-resume requires -checkpoint")
+	}
+
+	checkpoint, err := LoadCheckpoint(cfg.CheckpointPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewCheckpoint(cfg.CheckpointPath, cfg.Seed), nil
+	}
+
+	return checkpoint, err
+}
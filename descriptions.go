@@ -0,0 +1,56 @@
+package main
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+const (
+	DescriptionPlain = "plain"
+	DescriptionHTML  = "html"
+)
+
+// productDescription draws the description of the product called name in
+// cfg.DescriptionStyle with cfg.DescriptionParagraphs paragraphs. A single
+// plain paragraph is exactly one gofakeit.ProductDescription.
+func productDescription(cfg *Config, name string) string {
+	if cfg.DescriptionStyle != DescriptionHTML {
+		paragraphs := make([]string, cfg.DescriptionParagraphs)
+		for i := range paragraphs {
+			paragraphs[i] = gofakeit.ProductDescription()
+		}
+		return strings.Join(paragraphs, "\n\n")
+	}
+
+	// Every drawn string is escaped, so the markup stays well formed and
+	// carries nothing a theme would execute
+	var b strings.Builder
+	b.WriteString("<h2>" + html.EscapeString(name) + "</h2>\n")
+	b.WriteString("<p>" + html.EscapeString(gofakeit.ProductDescription()) + "</p>\n")
+
+	b.WriteString("<ul>\n")
+	numFeatures := gofakeit.IntRange(3, 5)
+	for i := 0; i < numFeatures; i++ {
+		b.WriteString("<li>" + html.EscapeString(capitalize(gofakeit.ProductFeature())) + "</li>\n")
+	}
+	b.WriteString("</ul>\n")
+
+	for i := 1; i < cfg.DescriptionParagraphs; i++ {
+		b.WriteString("<h3>" + html.EscapeString(capitalize(gofakeit.ProductBenefit())) + "</h3>\n")
+		b.WriteString("<p>" + html.EscapeString(gofakeit.ProductDescription()) + "</p>\n")
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var productCSVHeader = []string{"id", "name", "sku", "price", "brand", "categories", "inventory", "visible", "images", "variants"}
+
+// exportProductsCSV re-reads each product with its images and variants so the
+// sheet reflects what the store actually holds after enrichment.
+func exportProductsCSV(ctx context.Context, client *Client, path string, productIDs []int, brandNames, categoryNames map[int]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create csv export: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(productCSVHeader); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	params := &QueryParams{Include: []string{"images", "variants"}}
+	for _, productID := range productIDs {
+		response, err := client.Products.GetContext(ctx, productID, params)
+		if err != nil {
+			return fmt.Errorf("failed to get product %d for export: %w", productID, err)
+		}
+
+		if err := writer.Write(productCSVRow(&response.Data, brandNames, categoryNames)); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush csv export: %w", err)
+	}
+
+	return file.Close()
+}
+
+func productCSVRow(product *Product, brandNames, categoryNames map[int]string) []string {
+	categories := make([]string, 0, len(product.Categories))
+	for _, categoryID := range product.Categories {
+		if name, ok := categoryNames[categoryID]; ok {
+			categories = append(categories, name)
+		} else {
+			categories = append(categories, strconv.Itoa(categoryID))
+		}
+	}
+
+	brand := brandNames[product.BrandID]
+	if brand == "" && product.BrandID != 0 {
+		brand = strconv.Itoa(product.BrandID)
+	}
+
+	return []string{
+		strconv.Itoa(product.ID),
+		product.Name,
+		product.SKU,
+		strconv.FormatFloat(product.Price, 'f', 2, 64),
+		brand,
+		strings.Join(categories, "; "),
+		strconv.Itoa(product.InventoryLevel),
+		strconv.FormatBool(product.IsVisible),
+		strconv.Itoa(len(product.Images)),
+		strconv.Itoa(len(product.Variants)),
+	}
+}
+
+func namesByID(ids []int, names []string) map[int]string {
+	byID := make(map[int]string, len(ids))
+	for i, id := range ids {
+		if id != 0 && i < len(names) {
+			byID[id] = names[i]
+		}
+	}
+	return byID
+}
+
+// exportCatalogSnapshot writes the whole store's catalog, not only what this
+// run generated, as a snapshot for later diffs and syncs.
+func exportCatalogSnapshot(ctx context.Context, client *Client, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot export: %w", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	if err := WriteCatalogSnapshot(ctx, client, writer); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush snapshot export: %w", err)
+	}
+
+	return file.Close()
+}
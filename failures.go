@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// failureTally counts the run's failures by cause, so the summary says
+// whether they need fixed data, a slower run or new credentials.
+type failureTally struct {
+	Validation  int
+	RateLimited int
+	Auth        int
+	NotFound    int
+	Other       int
+}
+
+// Add counts each failure in err, descending into joined errors so a
+// stage's collected failures are counted one by one. Cancellations are
+// not failures and are skipped.
+func (t *failureTally) Add(err error) {
+	for cause := err; cause != nil; cause = errors.Unwrap(cause) {
+		if joined, ok := cause.(interface{ Unwrap() []error }); ok {
+			for _, err := range joined.Unwrap() {
+				t.Add(err)
+			}
+			return
+		}
+	}
+
+	switch {
+	case err == nil, errors.Is(err, context.Canceled):
+	case IsUnauthorized(err):
+		t.Auth++
+	case IsRateLimited(err):
+		t.RateLimited++
+	case IsNotFound(err):
+		t.NotFound++
+	case IsValidationError(err), errors.As(err, new(*VariantBatchError)):
+		t.Validation++
+	default:
+		t.Other++
+	}
+}
+
+// AddOptional counts err like Add but leaves its auth failures out, for
+// optional steps such as tax classes and gift wrapping that need v2 scopes
+// a token can lack, so a 403 there does not trip stopIfUnauthorized.
+func (t *failureTally) AddOptional(err error) {
+	auth := t.Auth
+	t.Add(err)
+	t.Auth = auth
+}
+
+// AddResults counts the failed enrichment steps of results.
+func (t *failureTally) AddResults(results []ProductResult) {
+	for _, result := range results {
+		for _, err := range result.Errors {
+			t.Add(err)
+		}
+	}
+}
+
+func (t *failureTally) Total() int {
+	return t.Validation + t.RateLimited + t.Auth + t.NotFound + t.Other
+}
+
+func (t *failureTally) String() string {
+	return fmt.Sprintf("%d validation errors, %d rate-limited, %d auth, %d not found, %d other",
+		t.Validation, t.RateLimited, t.Auth, t.NotFound, t.Other)
+}
@@ -2,4 +2,4 @@ module github.com/joshdurbin/bigcommerce-storefront-generator
 
 go 1.24.1
 
-require github.com/brianvoe/gofakeit/v7 v7.2.1 // indirect
+require github.com/brianvoe/gofakeit/v7 v7.2.1
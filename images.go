@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// defaultImageURLs is used when no image source is configured so generated
+// stores still show a variety of pictures.
+var defaultImageURLs = []string{
+	"https://picsum.photos/id/10/800/800",
+	"https://picsum.photos/id/20/800/800",
+	"https://picsum.photos/id/26/800/800",
+	"https://picsum.photos/id/30/800/800",
+	"https://picsum.photos/id/42/800/800",
+	"https://picsum.photos/id/48/800/800",
+	"https://picsum.photos/id/60/800/800",
+	"https://picsum.photos/id/96/800/800",
+}
+
+var imageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".gif":  true,
+	".webp": true,
+}
+
+// imageSource is either a URL for the API to fetch or a local file to
+// upload.
+type imageSource struct {
+	URL  string
+	Path string
+}
+
+// imagePool hands out images in rotation. Product images draw from both
+// the configured URLs and local files. Categories and brands can only
+// reference images by URL, so they fall back to defaultImageURLs when only
+// a directory was configured. With -no-images the pool hands out no URLs
+// at all.
+type imagePool struct {
+	mu       sync.Mutex
+	urls     []string
+	files    []string
+	next     int
+	nextURL  int
+	disabled bool
+}
+
+func newImagePool(cfg *Config) (*imagePool, error) {
+	pool := &imagePool{urls: cfg.ImageURLs, disabled: cfg.NoImages}
+
+	if cfg.ImageDir != "" {
+		entries, err := os.ReadDir(cfg.ImageDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read image directory: %w", err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && imageExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+				pool.files = append(pool.files, filepath.Join(cfg.ImageDir, entry.Name()))
+			}
+		}
+		if len(pool.files) == 0 {
+			return nil, fmt.Errorf("no images found in %s", cfg.ImageDir)
+		}
+	}
+
+	if len(pool.urls) == 0 && len(pool.files) == 0 {
+		pool.urls = defaultImageURLs
+	}
+
+	return pool, nil
+}
+
+// Next returns the next product image source.
+func (p *imagePool) Next() imageSource {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i := p.next % (len(p.urls) + len(p.files))
+	p.next++
+	if i < len(p.urls) {
+		return imageSource{URL: p.urls[i]}
+	}
+	return imageSource{Path: p.files[i-len(p.urls)]}
+}
+
+// NextURL returns the next image URL, for resources that cannot take an
+// upload, or "" when images are disabled.
+func (p *imagePool) NextURL() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.disabled {
+		return ""
+	}
+
+	urls := p.urls
+	if len(urls) == 0 {
+		urls = defaultImageURLs
+	}
+
+	url := urls[p.nextURL%len(urls)]
+	p.nextURL++
+	return url
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7/data"
+)
+
+// fakeLocale replaces the gofakeit word lists behind product names,
+// categories and descriptions. gofakeit itself only ships English data, so
+// anything not listed here, such as company names and lorem text, stays as
+// it is.
+type fakeLocale struct {
+	// Currency is the ISO 4217 code prices are expected to be read in.
+	// Prices themselves are always plain numbers.
+	Currency string
+	Product  map[string][]string
+	Colors   []string
+}
+
+var fakeLocales = map[string]fakeLocale{
+	"de": {
+		Currency: "EUR",
+		Product: map[string][]string{
+			"category":        {"Elektronik", "Bekleidung", "Haushalt", "Garten", "Spielwaren", "Sport", "Bücher", "Küche", "Möbel", "Schmuck", "Werkzeug", "Lebensmittel"},
+			"adjective":       {"praktisch", "elegant", "robust", "modern", "kompakt", "leicht", "klassisch", "nachhaltig"},
+			"name":            {"Rucksack", "Lampe", "Kaffeemaschine", "Uhr", "Stuhl", "Kopfhörer", "Tasche", "Becher", "Schal", "Kissen", "Messer", "Regal"},
+			"feature":         {"wasserdicht", "kabellos", "faltbar", "energiesparend", "verstellbar", "spülmaschinenfest"},
+			"material":        {"Holz", "Leder", "Edelstahl", "Baumwolle", "Glas", "Keramik", "Aluminium", "Wolle"},
+			"suffix":          {"Pro", "Plus", "Max", "Mini", "Classic", "Deluxe"},
+			"benefit":         {"lange Haltbarkeit", "hohen Komfort", "einfache Pflege", "mehr Ordnung", "bessere Leistung"},
+			"use_case":        {"den Alltag", "die Reise", "das Büro", "die Küche", "den Garten"},
+			"target_audience": {"Familien", "Studenten", "Profis", "Reisende", "Hobbyköche"},
+			"description": {
+				"Dieses Produkt aus {productmaterial} ist ideal für {productusecase} und bietet {productbenefit}.",
+				"Entwickelt für {productaudience}: {productfeature}, aus {productmaterial} und perfekt für {productusecase}.",
+				"Unser Modell {productname} überzeugt mit {productbenefit} und ist {productfeature}.",
+			},
+		},
+		Colors: []string{"schwarz", "weiß", "rot", "grün", "blau", "gelb", "grau", "braun", "silber", "orange"},
+	},
+	"fr": {
+		Currency: "EUR",
+		Product: map[string][]string{
+			"category":        {"Électronique", "Vêtements", "Maison", "Jardin", "Jouets", "Sport", "Livres", "Cuisine", "Meubles", "Bijoux", "Outillage", "Épicerie"},
+			"adjective":       {"pratique", "élégant", "robuste", "moderne", "compact", "léger", "classique", "durable"},
+			"name":            {"Sac à dos", "Lampe", "Cafetière", "Montre", "Chaise", "Casque", "Sacoche", "Tasse", "Écharpe", "Coussin", "Couteau", "Étagère"},
+			"feature":         {"étanche", "sans fil", "pliable", "économe en énergie", "réglable", "lavable au lave-vaisselle"},
+			"material":        {"bois", "cuir", "acier inoxydable", "coton", "verre", "céramique", "aluminium", "laine"},
+			"suffix":          {"Pro", "Plus", "Max", "Mini", "Classique", "Deluxe"},
+			"benefit":         {"une grande durabilité", "un confort optimal", "un entretien facile", "plus de rangement", "de meilleures performances"},
+			"use_case":        {"le quotidien", "les voyages", "le bureau", "la cuisine", "le jardin"},
+			"target_audience": {"les familles", "les étudiants", "les professionnels", "les voyageurs", "les cuisiniers amateurs"},
+			"description": {
+				"Ce produit en {productmaterial} est idéal pour {productusecase} et offre {productbenefit}.",
+				"Conçu pour {productaudience} : {productfeature}, en {productmaterial} et parfait pour {productusecase}.",
+				"Notre modèle {productname} se distingue par {productbenefit} et il est {productfeature}.",
+			},
+		},
+		Colors: []string{"noir", "blanc", "rouge", "vert", "bleu", "jaune", "gris", "marron", "argent", "orange"},
+	},
+}
+
+// applyLocale swaps in the word lists for locale. An empty locale keeps
+// gofakeit's English data.
+func applyLocale(locale string) (*fakeLocale, error) {
+	if locale == "" || locale == "en" {
+		return nil, nil
+	}
+
+	fake, ok := fakeLocales[strings.ToLower(locale)]
+	if !ok {
+		supported := make([]string, 0, len(fakeLocales)+1)
+		supported = append(supported, "en")
+		for name := range fakeLocales {
+			supported = append(supported, name)
+		}
+		sort.Strings(supported)
+		return nil, fmt.Errorf("unsupported locale %q, expected one of %s", locale, strings.Join(supported, ", "))
+	}
+
+	for key, words := range fake.Product {
+		data.SetSub("product", key, words)
+	}
+	data.SetSub("color", "safe", fake.Colors)
+
+	return &fake, nil
+}
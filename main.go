@@ -2,9 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
-	"math/rand"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -14,6 +24,7 @@ const (
 	// Configuration constants
 	StoreHash       = "yourstorehash"
 	AuthToken       = "yourauthtoken"
+	DefaultMarker   = "storefront-generator"
 	NumCategories   = 10
 	NumBrands       = 5
 	NumProducts     = 30
@@ -23,137 +34,678 @@ const (
 	MaxImages       = 3
 	MaxVideos       = 1
 	MaxReviews      = 5
+	MaxModifiers    = 3
+	NumGiftWrapping = 2
+
+	DefaultConcurrency = 4
+	MaxRetries         = 5
+)
+
+const (
+	InventoryUniform  = "uniform"
+	InventoryLongTail = "long-tail"
 )
 
+// optionNames are the options generated products draw from, in order.
+var optionNames = []string{"Color", "Size", "Material", "Style"}
+
+// defaultOptionValues are the built-in vocabularies; options without one,
+// built in or configured, get random values.
+var defaultOptionValues = map[string][]string{
+	"Size":     {"Small", "Medium", "Large", "X-Large", "XX-Large"},
+	"Material": {"Cotton", "Polyester", "Wool", "Leather", "Silk"},
+}
+
+type Config struct {
+	// SkipExisting matches products by SKU and brands and categories by
+	// name, reusing what is already in the store instead of duplicating it.
+	// Names and SKUs come from the seed, so it needs the seed of the run
+	// being repeated, given with -seed or recorded in a -resume checkpoint.
+	SkipExisting bool
+
+	// Seed drives all fake data; zero picks one from the clock. Reusing a
+	// seed regenerates the same names and SKUs, which is what makes
+	// resuming from a checkpoint possible. That holds for the same flags
+	// and the same version of the generator only: options that draw more
+	// data, and new draws added between versions, change what follows.
+	Seed int64
+
+	CheckpointPath string
+	Resume         bool
+
+	ExportCSVPath string
+
+	// ExportSnapshotPath, when set, receives the store's whole catalog as
+	// a JSON catalog snapshot.
+	ExportSnapshotPath string
+
+	// ManifestPath, when set, receives a JSON manifest of the IDs of
+	// everything the run created.
+	ManifestPath string
+
+	// Concurrency is how many products are enriched at once. Every worker
+	// shares one client, which pauses all of them when the store's rate
+	// limit is reached and retries what was rejected, so raising it only
+	// helps until that limit is the bottleneck.
+	Concurrency int
+
+	// Ratios are the fraction, in [0,1], of generated entities given each
+	// property. VisibleRatio applies to both categories and products.
+	VisibleRatio     float64
+	FeaturedRatio    float64
+	OutOfStockRatio  float64
+	BulkPricingRatio float64
+	ModifierRatio    float64
+	ComplexRuleRatio float64
+	DigitalRatio     float64
+
+	// InventoryDistribution is "uniform", levels drawn evenly from 0-99, or
+	// "long-tail": OutOfStockRatio at zero, LowStockRatio at or below their
+	// warning level and the rest spread over a realistic mid range.
+	InventoryDistribution string
+	LowStockRatio         float64
+
+	// MinOptionValues and MaxOptionValues bound how many values each
+	// option gets. OptionValues replaces the vocabulary of an option by
+	// name, e.g. a size run, from which values are taken in order; a
+	// vocabulary also caps the number of values.
+	MinOptionValues int
+	MaxOptionValues int
+	OptionValues    map[string][]string
+
+	// ReviewWindow, when positive, backdates reviews to random times within
+	// that long before the run. Reviews are created oldest first, so the
+	// order is realistic even where the API ignores the dates.
+	ReviewWindow time.Duration
+
+	// RelatedProducts is how many other generated products each product
+	// lists as related, once all of them exist.
+	RelatedProducts int
+
+	// DescriptionStyle is "plain" text or "html" with a heading, a feature
+	// list and DescriptionParagraphs paragraphs under subheadings, for
+	// testing how themes render and truncate long descriptions.
+	DescriptionStyle      string
+	DescriptionParagraphs int
+
+	// ShuffleSortOrder gives products a random sort order instead of their
+	// generation order, so default listings are not in creation order.
+	ShuffleSortOrder bool
+
+	// CategoryDepth is how many levels the category tree may have and
+	// CategoryChildren how many children each category gets before the next
+	// one is filled.
+	CategoryDepth    int
+	CategoryChildren int
+
+	// ImageURLs and the image files in ImageDir are rotated through for
+	// product, category and brand images.
+	ImageURLs []string
+	ImageDir  string
+
+	// VariantImages gives each variant an image, shared by variants of the
+	// same color, so apparel swatches preview a picture.
+	VariantImages bool
+
+	// CustomURLs sets a custom URL derived from the name on every product,
+	// category and brand, suffixed where names repeat within the run.
+	// Otherwise the store generates URLs itself.
+	CustomURLs bool
+
+	// The No* switches leave out an enrichment step for every product, for
+	// a minimal catalog or one that isolates a feature under test. NoImages
+	// also leaves categories and brands without images.
+	NoCustomFields bool
+	NoImages       bool
+	NoVideos       bool
+	NoVariants     bool
+	NoReviews      bool
+	NoBulkPricing  bool
+
+	// ChannelName, when set, creates a storefront channel on
+	// ChannelPlatform and lists every generated product on it too, for
+	// testing a second storefront against the same catalog.
+	ChannelName     string
+	ChannelPlatform string
+
+	// Locale selects localized product names, categories and descriptions.
+	// Empty keeps gofakeit's English data.
+	Locale string
+
+	// Marker tags everything the generator creates: it is added to the
+	// search keywords of products and brands and the meta keywords of
+	// categories, and used as the namespace of a metafield on each product.
+	// Searching the admin for it finds generated data.
+	Marker string
+}
+
+func (c *Config) Validate() error {
+	ratios := []struct {
+		name  string
+		value float64
+	}{
+		{"visible-ratio", c.VisibleRatio},
+		{"featured-ratio", c.FeaturedRatio},
+		{"out-of-stock-ratio", c.OutOfStockRatio},
+		{"bulk-pricing-ratio", c.BulkPricingRatio},
+		{"modifier-ratio", c.ModifierRatio},
+		{"complex-rule-ratio", c.ComplexRuleRatio},
+		{"digital-ratio", c.DigitalRatio},
+		{"low-stock-ratio", c.LowStockRatio},
+	}
+
+	var errs []error
+	for _, ratio := range ratios {
+		if ratio.value < 0 || ratio.value > 1 {
+			errs = append(errs, fmt.Errorf("-%s must be between 0 and 1, got %v", ratio.name, ratio.value))
+		}
+	}
+
+	if c.InventoryDistribution != InventoryUniform && c.InventoryDistribution != InventoryLongTail {
+		errs = append(errs, fmt.Errorf("-inventory-distribution must be %s or %s, got %q", InventoryUniform, InventoryLongTail, c.InventoryDistribution))
+	}
+	if c.OutOfStockRatio+c.LowStockRatio > 1 {
+		errs = append(errs, fmt.Errorf("-out-of-stock-ratio and -low-stock-ratio add up to more than 1"))
+	}
+
+	if c.SkipExisting && c.Seed == 0 && !c.Resume {
+		errs = append(errs, errors.New("-skip-existing needs the -seed of the run being repeated, or -resume"))
+	}
+
+	if c.Concurrency < 1 {
+		errs = append(errs, fmt.Errorf("-concurrency must be at least 1, got %d", c.Concurrency))
+	}
+
+	if c.MinOptionValues < 1 {
+		errs = append(errs, fmt.Errorf("-min-option-values must be at least 1, got %d", c.MinOptionValues))
+	}
+	if c.MaxOptionValues < c.MinOptionValues {
+		errs = append(errs, fmt.Errorf("-max-option-values must be at least -min-option-values, got %d", c.MaxOptionValues))
+	}
+	for name, values := range c.OptionValues {
+		if !slices.Contains(optionNames, name) {
+			errs = append(errs, fmt.Errorf("-option-values: unknown option %q, must be one of %s", name, strings.Join(optionNames, ", ")))
+		} else if len(values) < c.MinOptionValues {
+			errs = append(errs, fmt.Errorf("-option-values: %s has %d values, fewer than -min-option-values", name, len(values)))
+		}
+	}
+
+	if c.ReviewWindow < 0 {
+		errs = append(errs, fmt.Errorf("-review-window must not be negative, got %s", c.ReviewWindow))
+	}
+
+	if c.RelatedProducts < 0 {
+		errs = append(errs, fmt.Errorf("-related-products must not be negative, got %d", c.RelatedProducts))
+	}
+
+	if c.DescriptionStyle != DescriptionPlain && c.DescriptionStyle != DescriptionHTML {
+		errs = append(errs, fmt.Errorf("-description-style must be %s or %s, got %q", DescriptionPlain, DescriptionHTML, c.DescriptionStyle))
+	}
+	if c.DescriptionParagraphs < 1 {
+		errs = append(errs, fmt.Errorf("-description-paragraphs must be at least 1, got %d", c.DescriptionParagraphs))
+	}
+
+	if c.CategoryDepth < 1 {
+		errs = append(errs, fmt.Errorf("-category-depth must be at least 1, got %d", c.CategoryDepth))
+	}
+	if c.CategoryChildren < 1 {
+		errs = append(errs, fmt.Errorf("-category-children must be at least 1, got %d", c.CategoryChildren))
+	}
+
+	if c.NoImages && c.VariantImages {
+		errs = append(errs, errors.New("-variant-images cannot be used with -no-images"))
+	}
+
+	if c.ChannelName != "" && c.ChannelPlatform == "" {
+		errs = append(errs, errors.New("-channel-platform must be set with -channel-name"))
+	}
+
+	if c.Marker == "" || strings.Contains(c.Marker, ",") {
+		errs = append(errs, fmt.Errorf("-marker must be non-empty and contain no commas, got %q", c.Marker))
+	} else if len(c.Marker) > maxMetafieldNamespaceLength {
+		// It doubles as the namespace of each product's marker metafield
+		errs = append(errs, fmt.Errorf("-marker must be at most %d characters, got %d", maxMetafieldNamespaceLength, len(c.Marker)))
+	}
+
+	return errors.Join(errs...)
+}
+
+func loadConfig() *Config {
+	cfg := &Config{}
+
+	flag.BoolVar(&cfg.SkipExisting, "skip-existing", false, "reuse products, brands and categories that already exist instead of creating duplicates; needs -seed or -resume")
+	flag.Int64Var(&cfg.Seed, "seed", 0, "random seed for reproducible data (0 uses the current time)")
+	flag.StringVar(&cfg.CheckpointPath, "checkpoint", "", "file to periodically record created entities in")
+	flag.BoolVar(&cfg.Resume, "resume", false, "skip work already recorded in the -checkpoint file")
+	flag.StringVar(&cfg.ExportCSVPath, "export-csv", "", "write a CSV summary of created products to this file")
+	flag.StringVar(&cfg.ExportSnapshotPath, "export-snapshot", "", "write the store's catalog to this file as a JSON snapshot")
+	flag.StringVar(&cfg.ManifestPath, "manifest", "", "write a JSON manifest of created IDs to this file")
+	flag.IntVar(&cfg.Concurrency, "concurrency", DefaultConcurrency, "number of products enriched in parallel, sharing the store's rate limit")
+	flag.Float64Var(&cfg.VisibleRatio, "visible-ratio", 0.9, "fraction of categories and products that are visible")
+	flag.Float64Var(&cfg.FeaturedRatio, "featured-ratio", 0.2, "fraction of products that are featured")
+	flag.Float64Var(&cfg.OutOfStockRatio, "out-of-stock-ratio", 0, "fraction of products and variants created with no inventory")
+	flag.StringVar(&cfg.InventoryDistribution, "inventory-distribution", InventoryUniform, "how inventory levels are drawn: uniform or long-tail")
+	flag.Float64Var(&cfg.LowStockRatio, "low-stock-ratio", 0.2, "fraction of products and variants at or below their warning level with -inventory-distribution=long-tail")
+	flag.Float64Var(&cfg.BulkPricingRatio, "bulk-pricing-ratio", 0.3, "fraction of products given bulk pricing rules")
+	flag.Float64Var(&cfg.ModifierRatio, "modifier-ratio", 0.3, "fraction of products given modifiers such as text or file inputs")
+	flag.Float64Var(&cfg.ComplexRuleRatio, "complex-rule-ratio", 0.3, "fraction of products with two or more options given a complex rule")
+	flag.Float64Var(&cfg.DigitalRatio, "digital-ratio", 0, "fraction of products that are digital, with no dimensions, shipping or stock")
+	flag.IntVar(&cfg.MinOptionValues, "min-option-values", 2, "minimum number of values per product option")
+	flag.IntVar(&cfg.MaxOptionValues, "max-option-values", 4, "maximum number of values per product option")
+	flag.Func("option-values", "values for an option as Name=a,b,c, e.g. Size=S,M,L,XL; repeatable", func(value string) error {
+		name, list, ok := strings.Cut(value, "=")
+		if !ok {
+			return fmt.Errorf("expected Name=value,value, got %q", value)
+		}
+
+		var values []string
+		for _, v := range strings.Split(list, ",") {
+			if v = strings.TrimSpace(v); v != "" && !slices.Contains(values, v) {
+				values = append(values, v)
+			}
+		}
+
+		if cfg.OptionValues == nil {
+			cfg.OptionValues = make(map[string][]string)
+		}
+		cfg.OptionValues[strings.TrimSpace(name)] = values
+		return nil
+	})
+	flag.DurationVar(&cfg.ReviewWindow, "review-window", 0, "backdate reviews across this long before now, e.g. 2160h for 90 days (0 leaves dates to the store)")
+	flag.IntVar(&cfg.RelatedProducts, "related-products", 0, "number of other generated products each product lists as related")
+	flag.StringVar(&cfg.DescriptionStyle, "description-style", DescriptionPlain, "product description format: plain or html")
+	flag.IntVar(&cfg.DescriptionParagraphs, "description-paragraphs", 1, "number of paragraphs in each product description")
+	flag.BoolVar(&cfg.ShuffleSortOrder, "shuffle-sort-order", false, "give products a random sort order instead of their generation order")
+	flag.IntVar(&cfg.CategoryDepth, "category-depth", 3, "maximum number of levels in the category tree")
+	flag.IntVar(&cfg.CategoryChildren, "category-children", 3, "number of top-level categories and of children per category")
+	flag.Func("image-urls", "comma-separated image URLs to use instead of the built-in placeholders", func(value string) error {
+		for _, url := range strings.Split(value, ",") {
+			if url = strings.TrimSpace(url); url != "" {
+				cfg.ImageURLs = append(cfg.ImageURLs, url)
+			}
+		}
+		return nil
+	})
+	flag.StringVar(&cfg.ImageDir, "image-dir", "", "directory of images to upload as product images")
+	flag.BoolVar(&cfg.VariantImages, "variant-images", false, "assign an image to each variant, one per color")
+	flag.BoolVar(&cfg.CustomURLs, "custom-urls", false, "give products, categories and brands unique URLs derived from their names")
+	flag.BoolVar(&cfg.NoCustomFields, "no-custom-fields", false, "create products without custom fields")
+	flag.BoolVar(&cfg.NoImages, "no-images", false, "create products, categories and brands without images")
+	flag.BoolVar(&cfg.NoVideos, "no-videos", false, "create products without videos")
+	flag.BoolVar(&cfg.NoVariants, "no-variants", false, "create products without options and variants, and so without complex rules")
+	flag.BoolVar(&cfg.NoReviews, "no-reviews", false, "create products without reviews")
+	flag.BoolVar(&cfg.NoBulkPricing, "no-bulk-pricing", false, "create products without bulk pricing rules")
+	flag.StringVar(&cfg.ChannelName, "channel-name", "", "create a storefront channel with this name and list generated products on it")
+	flag.StringVar(&cfg.ChannelPlatform, "channel-platform", "custom", "platform of the channel created with -channel-name")
+	flag.StringVar(&cfg.Locale, "locale", "", "language for generated product data (de, fr; default English)")
+	flag.StringVar(&cfg.Marker, "marker", DefaultMarker, "token added to generated entities so they can be found later")
+	flag.Parse()
+
+	return cfg
+}
+
 func main() {
+	cfg := loadConfig()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+
+	checkpoint, err := openCheckpoint(cfg)
+	if err != nil {
+		log.Fatalf("Failed to open checkpoint: %v", err)
+	}
+	if checkpoint.Seed != cfg.Seed {
+		log.Printf("Resuming with checkpoint seed %d", checkpoint.Seed)
+		cfg.Seed = checkpoint.Seed
+	}
+
+	// The first interrupt cancels ctx so the run winds down and saves its
+	// checkpoint; a second one exits immediately
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interrupts := make(chan os.Signal, 2)
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interrupts
+		log.Println("Interrupted, stopping after in-flight requests")
+		cancel()
+		<-interrupts
+		log.Println("Interrupted again, saving checkpoint")
+		if err := checkpoint.Save(); err != nil {
+			log.Printf("Failed to save checkpoint: %v", err)
+		}
+		os.Exit(130)
+	}()
+
 	// Seed the random generator
-	gofakeit.Seed(time.Now().UnixNano())
-	rand.Seed(time.Now().UnixNano())
+	gofakeit.Seed(cfg.Seed)
+	log.Printf("Using seed %d", cfg.Seed)
 
-	// Initialize the BigCommerce client
-	client := NewClient(StoreHash, AuthToken)
+	locale, err := applyLocale(cfg.Locale)
+	if err != nil {
+		log.Fatalf("Failed to apply locale: %v", err)
+	}
+	if locale != nil {
+		log.Printf("Generating %s data, prices are in %s", cfg.Locale, locale.Currency)
+	}
 
-	// Create context
-	ctx := context.Background()
+	images, err := newImagePool(cfg)
+	if err != nil {
+		log.Fatalf("Failed to load images: %v", err)
+	}
+
+	// Shared so products, categories and brands never claim the same URL
+	slugs := newSlugger(cfg)
+	skus := newSKUPool()
+
+	// Initialize the BigCommerce client
+	// No fixed pacing: the client waits out the store's quota window once
+	// it is spent and retries any request rejected with a 429. Jitter keeps
+	// the enrichment workers from retrying gateway errors in step
+	client := NewClient(StoreHash, AuthToken, WithRateLimit(0), WithRetry(MaxRetries), WithRetryJitter())
+	failures := &failureTally{}
 
 	// Generate and create categories
-	categories := generateCategories()
-	categoryIDs, err := createCategories(ctx, client, categories)
+	categories, parents := generateCategories(cfg, images, slugs)
+	categoryIDs, skippedCategories, err := createCategories(ctx, client, cfg, checkpoint, categories, parents)
 	if err != nil {
-		log.Fatalf("Failed to create categories: %v", err)
+		log.Printf("Failed to create some categories: %v", err)
+	}
+	failures.Add(err)
+	stopIfUnauthorized(failures, checkpoint)
+	stopIfCancelled(ctx, checkpoint)
+	createdCategoryIDs := nonZero(categoryIDs)
+	if len(createdCategoryIDs) == 0 {
+		log.Fatalf("No categories available for products")
 	}
-	log.Printf("Created %d categories", len(categoryIDs))
+	log.Printf("Created %d categories, skipped %d existing", len(createdCategoryIDs)-skippedCategories, skippedCategories)
+	log.Printf("Category tree: %s", categoryTreeShape(parents))
 
 	// Generate and create brands
-	brands := generateBrands()
-	brandIDs, err := createBrands(ctx, client, brands)
+	brands := generateBrands(cfg, images, slugs)
+	brandIDs, skippedBrands, err := createBrands(ctx, client, cfg, checkpoint, brands)
+	if err != nil {
+		log.Printf("Failed to create some brands: %v", err)
+	}
+	failures.Add(err)
+	stopIfUnauthorized(failures, checkpoint)
+	stopIfCancelled(ctx, checkpoint)
+	createdBrandIDs := nonZero(brandIDs)
+	if len(createdBrandIDs) == 0 {
+		log.Fatalf("No brands available for products")
+	}
+	log.Printf("Created %d brands, skipped %d existing", len(createdBrandIDs)-skippedBrands, skippedBrands)
+
+	// Look up tax classes so products reference real IDs
+	taxClassIDs, err := listTaxClassIDs(ctx, client)
+	if err != nil {
+		log.Printf("Failed to list tax classes, leaving tax class unset: %v", err)
+	}
+	failures.AddOptional(err)
+
+	// Create gift wrapping options for products to offer
+	giftWrappingOptions := generateGiftWrappingOptions()
+	giftWrappingIDs, err := createGiftWrappingOptions(ctx, client, checkpoint, giftWrappingOptions)
 	if err != nil {
-		log.Fatalf("Failed to create brands: %v", err)
+		log.Printf("Failed to create gift wrapping options: %v", err)
 	}
-	log.Printf("Created %d brands", len(brandIDs))
+	failures.AddOptional(err)
 
 	// Generate and create products
-	products := generateProducts(categoryIDs, brandIDs)
-	productIDs, err := createProducts(ctx, client, products)
+	products := generateProducts(cfg, slugs, skus, createdCategoryIDs, createdBrandIDs, taxClassIDs, giftWrappingIDs)
+	productIDs, skippedProducts, err := createProducts(ctx, client, cfg, checkpoint, products)
 	if err != nil {
-		log.Fatalf("Failed to create products: %v", err)
+		log.Printf("Failed to create some products: %v", err)
+	}
+	failures.Add(err)
+	stopIfUnauthorized(failures, checkpoint)
+	stopIfCancelled(ctx, checkpoint)
+	log.Printf("Created or resumed %d products, skipped %d existing", len(productIDs), skippedProducts)
+
+	// Give each category page a stable merchandised order
+	if err := assignCategorySortOrders(ctx, client, checkpoint, products); err != nil {
+		log.Printf("Failed to set some category sort orders: %v", err)
+		failures.Add(err)
 	}
-	log.Printf("Created %d products", len(productIDs))
 
-	// For each product, add additional data
-	for _, productID := range productIDs {
-		// Add custom fields
-		if err := addCustomFields(ctx, client, productID); err != nil {
-			log.Printf("Failed to add custom fields for product %d: %v", productID, err)
-			continue
-		}
+	// Link products to each other for "you may also like" listings
+	if err := relateProducts(ctx, client, cfg, productIDs); err != nil {
+		log.Printf("Failed to relate some products: %v", err)
+		failures.Add(err)
+	}
+	stopIfUnauthorized(failures, checkpoint)
 
-		// Add images
-		if err := addProductImages(ctx, client, productID); err != nil {
-			log.Printf("Failed to add images for product %d: %v", productID, err)
+	// Optionally list the catalog on a channel of its own. Channel failures
+	// stay out of the tally, as plans without spare channels also answer 403
+	channelID := 0
+	if cfg.ChannelName != "" {
+		channelID, err = createChannel(ctx, client, cfg, checkpoint)
+		if err != nil {
+			log.Printf("Failed to create channel %q, products stay on the default channel only: %v", cfg.ChannelName, err)
+		} else if err := assignProductsToChannel(ctx, client, channelID, productIDs); err != nil {
+			log.Printf("Failed to list some products on channel %d: %v", channelID, err)
 		}
+	}
+	stopIfCancelled(ctx, checkpoint)
+
+	// For each product, add additional data
+	results := enrichProducts(ctx, client, cfg, checkpoint, images, skus, productIDs)
+	if created := summarizeCreated(results); created != "" {
+		log.Printf("Enrichment created %s", created)
+	}
+	if incomplete := summarizeResults(results); incomplete != "" {
+		log.Printf("Some products are incomplete: %s", incomplete)
+	}
+	failures.AddResults(results)
+	stopIfUnauthorized(failures, checkpoint)
+	stopIfCancelled(ctx, checkpoint)
+
+	if err := checkpoint.Save(); err != nil {
+		log.Printf("Failed to save checkpoint: %v", err)
+	}
 
-		// Add videos
-		if err := addProductVideos(ctx, client, productID); err != nil {
-			log.Printf("Failed to add videos for product %d: %v", productID, err)
+	// Export a spreadsheet-friendly summary
+	if cfg.ExportCSVPath != "" {
+		categoryNames := make([]string, len(categories))
+		for i, category := range categories {
+			categoryNames[i] = category.Name
+		}
+		brandNames := make([]string, len(brands))
+		for i, brand := range brands {
+			brandNames[i] = brand.Name
 		}
 
-		// Add options and variants
-		if err := addOptionsAndVariants(ctx, client, productID); err != nil {
-			log.Printf("Failed to add options and variants for product %d: %v", productID, err)
+		err := exportProductsCSV(ctx, client, cfg.ExportCSVPath, productIDs, namesByID(brandIDs, brandNames), namesByID(categoryIDs, categoryNames))
+		if err != nil {
+			log.Printf("Failed to export products to CSV: %v", err)
+			failures.Add(err)
+		} else {
+			log.Printf("Exported %d products to %s", len(productIDs), cfg.ExportCSVPath)
 		}
+	}
 
-		// Add reviews
-		if err := addProductReviews(ctx, client, productID); err != nil {
-			log.Printf("Failed to add reviews for product %d: %v", productID, err)
+	if cfg.ExportSnapshotPath != "" {
+		if err := exportCatalogSnapshot(ctx, client, cfg.ExportSnapshotPath); err != nil {
+			log.Printf("Failed to export catalog snapshot: %v", err)
+			failures.Add(err)
+		} else {
+			log.Printf("Exported catalog snapshot to %s", cfg.ExportSnapshotPath)
 		}
+	}
 
-		// Add bulk pricing rules
-		if err := addBulkPricingRules(ctx, client, productID); err != nil {
-			log.Printf("Failed to add bulk pricing rules for product %d: %v", productID, err)
+	if cfg.ManifestPath != "" {
+		manifest := newManifest(cfg, checkpoint, categories, categoryIDs, brands, brandIDs, products, results)
+		manifest.Channel = channelID
+		manifest.GiftWrapping = giftWrappingIDs
+		if err := writeManifest(cfg.ManifestPath, manifest); err != nil {
+			log.Printf("Failed to write manifest: %v", err)
+		} else {
+			log.Printf("Wrote manifest of created IDs to %s", cfg.ManifestPath)
 		}
 	}
 
+	if channelID != 0 {
+		log.Printf("Products are listed on channel %q (ID: %d)", cfg.ChannelName, channelID)
+	}
+
+	if failures.Total() > 0 {
+		log.Printf("Failures: %s", failures)
+	}
+
 	log.Println("Finished creating store catalog data!")
 }
 
-func generateCategories() []Category {
+// generateCategories builds the tree breadth first: CategoryChildren
+// top-level categories, then CategoryChildren children under each category
+// in turn until CategoryDepth is reached, with any remainder placed at the
+// top level. The returned parents hold each category's parent index, or -1
+// for top-level categories; a parent always precedes its children.
+func generateCategories(cfg *Config, images *imagePool, slugs *slugger) ([]Category, []int) {
 	categories := make([]Category, NumCategories)
+	parents := make([]int, NumCategories)
+	depths := make([]int, NumCategories)
+	siblings := make(map[int]int)
+
+	next := 0 // index of the category currently receiving children
+	for i := 0; i < NumCategories; i++ {
+		parent := -1
+		if i >= cfg.CategoryChildren {
+			for next < i && (depths[next] >= cfg.CategoryDepth || siblings[next] >= cfg.CategoryChildren) {
+				next++
+			}
+			if next < i {
+				parent = next
+			}
+		}
 
-	// First category is top-level
-	categories[0] = Category{
-		ParentID:        0,
-		Name:            gofakeit.ProductCategory(),
-		Description:     gofakeit.ProductDescription(),
-		SortOrder:       0,
-		PageTitle:       gofakeit.Sentence(3),
-		MetaKeywords:    []string{gofakeit.Word(), gofakeit.Word(), gofakeit.Word()},
-		MetaDescription: gofakeit.Paragraph(1, 2, 3, " "),
-		LayoutFile:      "category.html",
-		IsVisible:       true,
-		ImageURL:        "https://images.pexels.com/photos/45201/kitty-cat-kitten-pet-45201.jpeg",
-	}
-
-	// Rest can be children
-	for i := 1; i < NumCategories; i++ {
-		parentID := 0
-		if i > 2 { // Some categories have parents
-			parentID = rand.Intn(i) // Parent must have lower ID
+		parents[i] = parent
+		if parent >= 0 {
+			depths[i] = depths[parent] + 1
+		} else {
+			depths[i] = 1
 		}
+		sortOrder := siblings[parent]
+		siblings[parent]++
+
+		// The first category is always visible so the storefront has a
+		// navigable root
+		isVisible := i == 0 || chance(gofakeit.GlobalFaker, cfg.VisibleRatio)
 
+		name := gofakeit.ProductCategory()
 		categories[i] = Category{
-			ParentID:        parentID,
-			Name:            gofakeit.ProductCategory(),
+			Name:            name,
 			Description:     gofakeit.ProductDescription(),
-			SortOrder:       i,
+			SortOrder:       sortOrder,
 			PageTitle:       gofakeit.Sentence(3),
-			MetaKeywords:    []string{gofakeit.Word(), gofakeit.Word(), gofakeit.Word()},
+			MetaKeywords:    []string{gofakeit.Word(), gofakeit.Word(), gofakeit.Word(), cfg.Marker},
 			MetaDescription: gofakeit.Paragraph(1, 2, 3, " "),
 			LayoutFile:      "category.html",
-			IsVisible:       rand.Float32() > 0.1, // 90% visible
-			ImageURL:        "https://images.pexels.com/photos/45201/kitty-cat-kitten-pet-45201.jpeg",
+			IsVisible:       isVisible,
+			ImageURL:        images.NextURL(),
+			CustomURL:       slugs.CustomURL(name),
 		}
 	}
 
-	return categories
+	return categories, parents
 }
 
-func createCategories(ctx context.Context, client *Client, categories []Category) ([]int, error) {
-	categoryIDs := make([]int, 0, len(categories))
+// categoryTreeShape summarizes how many categories sit at each level.
+func categoryTreeShape(parents []int) string {
+	depths := make([]int, len(parents))
+	var counts []int
+	for i, parent := range parents {
+		if parent >= 0 {
+			depths[i] = depths[parent] + 1
+		}
+		for len(counts) <= depths[i] {
+			counts = append(counts, 0)
+		}
+		counts[depths[i]]++
+	}
+
+	levels := make([]string, len(counts))
+	for depth, count := range counts {
+		levels[depth] = fmt.Sprintf("level %d: %d", depth+1, count)
+	}
+	return strings.Join(levels, ", ")
+}
+
+// createCategories returns the IDs of every category, existing or created,
+// along with how many were reused. parents holds each category's parent
+// index as returned by generateCategories. IDs stay aligned with
+// categories, with zero for any that failed; failures do not stop the
+// loop, but cancelling ctx or rejected credentials do, and all of them are
+// returned joined.
+func createCategories(ctx context.Context, client *Client, cfg *Config, checkpoint *Checkpoint, categories []Category, parents []int) ([]int, int, error) {
+	categoryIDs := make([]int, len(categories))
+	skipped := 0
+	var errs []error
+
+	for i, category := range categories {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		// Parents are generated first, so their IDs are already known.
+		// ParentID only ever holds an ID the store returned, never an index
+		if parents[i] >= i {
+			errs = append(errs, fmt.Errorf("category %q: parent %d is not generated before it", category.Name, parents[i]))
+			continue
+		}
+		if parents[i] >= 0 {
+			category.ParentID = categoryIDs[parents[i]]
+			if category.ParentID == 0 {
+				errs = append(errs, fmt.Errorf("category %q: parent was not created", category.Name))
+				continue
+			}
+		}
+
+		if id, ok := checkpoint.Category(i, category.Name); ok {
+			categoryIDs[i] = id
+			skipped++
+			continue
+		}
+
+		if cfg.SkipExisting {
+			existing, err := client.Categories.GetByNameContext(ctx, category.Name, category.ParentID)
+			if err == nil {
+				categoryIDs[i] = existing.Data.ID
+				skipped++
+				log.Printf("Skipped existing category: %s (ID: %d)", category.Name, existing.Data.ID)
+				continue
+			}
+			if !IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to look up category %q: %w", category.Name, err))
+				if IsUnauthorized(err) {
+					break
+				}
+				continue
+			}
+		}
 
-	for _, category := range categories {
 		response, err := client.Categories.CreateContext(ctx, &category)
 		if err != nil {
-			return categoryIDs, fmt.Errorf("failed to create category: %v", err)
+			errs = append(errs, fmt.Errorf("failed to create category %q: %w", category.Name, err))
+			if IsUnauthorized(err) {
+				break
+			}
+			continue
 		}
-		categoryIDs = append(categoryIDs, response.Data.ID)
+		categoryIDs[i] = response.Data.ID
+		checkpoint.RecordCategory(i, category.Name, response.Data.ID)
 		log.Printf("Created category: %s (ID: %d)", category.Name, response.Data.ID)
 	}
 
-	return categoryIDs, nil
+	return categoryIDs, skipped, errors.Join(errs...)
 }
 
-func generateBrands() []Brand {
+func generateBrands(cfg *Config, images *imagePool, slugs *slugger) []Brand {
 	brands := make([]Brand, NumBrands)
 
 	for i := 0; i < NumBrands; i++ {
@@ -163,38 +715,200 @@ func generateBrands() []Brand {
 			PageTitle:       brandName + " Products",
 			MetaKeywords:    []string{brandName, gofakeit.Word(), gofakeit.Word()},
 			MetaDescription: gofakeit.Paragraph(1, 2, 3, " "),
-			ImageURL:        "https://images.pexels.com/photos/45201/kitty-cat-kitten-pet-45201.jpeg",
-			SearchKeywords:  gofakeit.Word() + ", " + gofakeit.Word(),
+			ImageURL:        images.NextURL(),
+			SearchKeywords:  gofakeit.Word() + ", " + gofakeit.Word() + ", " + cfg.Marker,
+			CustomURL:       slugs.CustomURL(brandName),
 		}
 	}
 
 	return brands
 }
 
-func createBrands(ctx context.Context, client *Client, brands []Brand) ([]int, error) {
-	brandIDs := make([]int, 0, len(brands))
+// createBrands returns the IDs of every brand, existing or created, along
+// with how many were reused. Like createCategories, IDs stay aligned with
+// brands and failures are collected rather than stopping the loop.
+func createBrands(ctx context.Context, client *Client, cfg *Config, checkpoint *Checkpoint, brands []Brand) ([]int, int, error) {
+	brandIDs := make([]int, len(brands))
+	skipped := 0
+	var errs []error
+
+	for i, brand := range brands {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		if id, ok := checkpoint.Brand(brand.Name); ok {
+			brandIDs[i] = id
+			skipped++
+			continue
+		}
+
+		if cfg.SkipExisting {
+			existing, err := client.Brands.GetByNameContext(ctx, brand.Name)
+			if err == nil {
+				brandIDs[i] = existing.Data.ID
+				skipped++
+				log.Printf("Skipped existing brand: %s (ID: %d)", brand.Name, existing.Data.ID)
+				continue
+			}
+			if !IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to look up brand %q: %w", brand.Name, err))
+				if IsUnauthorized(err) {
+					break
+				}
+				continue
+			}
+		}
 
-	for _, brand := range brands {
 		response, err := client.Brands.CreateContext(ctx, &brand)
 		if err != nil {
-			return brandIDs, fmt.Errorf("failed to create brand: %v", err)
+			errs = append(errs, fmt.Errorf("failed to create brand %q: %w", brand.Name, err))
+			if IsUnauthorized(err) {
+				break
+			}
+			continue
 		}
-		brandIDs = append(brandIDs, response.Data.ID)
+		brandIDs[i] = response.Data.ID
+		checkpoint.RecordBrand(brand.Name, response.Data.ID)
 		log.Printf("Created brand: %s (ID: %d)", brand.Name, response.Data.ID)
 	}
 
-	return brandIDs, nil
+	return brandIDs, skipped, errors.Join(errs...)
+}
+
+func listTaxClassIDs(ctx context.Context, client *Client) ([]int, error) {
+	taxClasses, err := client.TaxClasses.ListContext(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	taxClassIDs := make([]int, 0, len(taxClasses))
+	for _, taxClass := range taxClasses {
+		taxClassIDs = append(taxClassIDs, taxClass.ID)
+	}
+
+	return taxClassIDs, nil
+}
+
+func generateGiftWrappingOptions() []GiftWrappingOption {
+	options := make([]GiftWrappingOption, NumGiftWrapping)
+
+	for i := 0; i < NumGiftWrapping; i++ {
+		options[i] = GiftWrappingOption{
+			Name:          gofakeit.Color() + " Gift Wrap",
+			AllowComments: i == 0,
+			Price:         fmt.Sprintf("%.2f", gofakeit.Price(1, 10)),
+		}
+	}
+
+	return options
+}
+
+func createGiftWrappingOptions(ctx context.Context, client *Client, checkpoint *Checkpoint, options []GiftWrappingOption) ([]int, error) {
+	if optionIDs, ok := checkpoint.GiftWrappingIDs(); ok {
+		return optionIDs, nil
+	}
+
+	optionIDs := make([]int, 0, len(options))
+
+	for _, option := range options {
+		response, err := client.GiftWrapping.CreateContext(ctx, &option)
+		if err != nil {
+			return optionIDs, fmt.Errorf("failed to create gift wrapping option: %w", err)
+		}
+		optionIDs = append(optionIDs, response.ID)
+		log.Printf("Created gift wrapping option: %s (ID: %d)", option.Name, response.ID)
+	}
+
+	checkpoint.RecordGiftWrapping(optionIDs)
+
+	return optionIDs, nil
+}
+
+// createChannel creates the -channel-name storefront channel, or returns
+// the one a checkpointed run already created. Stores whose plan does not
+// allow another channel reject it, which the caller treats as non-fatal.
+func createChannel(ctx context.Context, client *Client, cfg *Config, checkpoint *Checkpoint) (int, error) {
+	if channelID, ok := checkpoint.ChannelID(); ok {
+		return channelID, nil
+	}
+
+	channel := &Channel{
+		Name:     cfg.ChannelName,
+		Type:     "storefront",
+		Platform: cfg.ChannelPlatform,
+		Status:   "prelaunch",
+	}
+
+	response, err := client.Channels.CreateContext(ctx, channel)
+	if IsUnauthorized(err) {
+		return 0, fmt.Errorf("channel creation is not permitted for this store or token: %w", err)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	checkpoint.RecordChannel(response.Data.ID)
+	log.Printf("Created channel: %s (ID: %d)", channel.Name, response.Data.ID)
+
+	return response.Data.ID, nil
 }
 
-func generateProducts(categoryIDs, brandIDs []int) []Product {
+func assignProductsToChannel(ctx context.Context, client *Client, channelID int, productIDs []int) error {
+	var errs []error
+	for _, productID := range productIDs {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if _, err := client.ProductChannelAssignments.CreateContext(ctx, productID, []int{channelID}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to assign product %d: %w", productID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// relateProducts gives each product cfg.RelatedProducts related products
+// drawn from the others in productIDs, capped by how many there are.
+func relateProducts(ctx context.Context, client *Client, cfg *Config, productIDs []int) error {
+	numRelated := min(cfg.RelatedProducts, len(productIDs)-1)
+	if numRelated <= 0 {
+		return nil
+	}
+
+	var errs []error
+	for i, productID := range productIDs {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		// A partial shuffle of the other products picks distinct ones
+		others := slices.Delete(slices.Clone(productIDs), i, i+1)
+		for j := 0; j < numRelated; j++ {
+			k := j + gofakeit.IntN(len(others)-j)
+			others[j], others[k] = others[k], others[j]
+		}
+
+		if _, err := client.RelatedProducts.CreateContext(ctx, productID, others[:numRelated]); err != nil {
+			errs = append(errs, fmt.Errorf("failed to relate products to product %d: %w", productID, err))
+			if IsUnauthorized(err) {
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func generateProducts(cfg *Config, slugs *slugger, skus *skuPool, categoryIDs, brandIDs, taxClassIDs, giftWrappingIDs []int) []Product {
 	products := make([]Product, NumProducts)
 
 	for i := 0; i < NumProducts; i++ {
-		// Select random categories (1-3)
-		numCats := rand.Intn(3) + 1
+		// Select random categories (1-3), the first being the product's
+		// primary category
+		numCats := gofakeit.IntN(3) + 1
 		categories := make([]int, 0, numCats)
 		for j := 0; j < numCats; j++ {
-			catID := categoryIDs[rand.Intn(len(categoryIDs))]
+			catID := categoryIDs[gofakeit.IntN(len(categoryIDs))]
 			// Check if already added
 			alreadyAdded := false
 			for _, c := range categories {
@@ -209,19 +923,34 @@ func generateProducts(categoryIDs, brandIDs []int) []Product {
 		}
 
 		// Select random brand
-		brandID := brandIDs[rand.Intn(len(brandIDs))]
+		brandID := brandIDs[gofakeit.IntN(len(brandIDs))]
+
+		// Select random tax class, if any exist. The draw is made either
+		// way, as the lookup can fail on one run and not the next, and the
+		// names and SKUs after it must not shift
+		taxClassPick := gofakeit.Float64()
+		taxClassID := 0
+		if len(taxClassIDs) > 0 {
+			taxClassID = taxClassIDs[int(taxClassPick*float64(len(taxClassIDs)))]
+		}
+
+		// Offer the created gift wrapping, otherwise leave the store default
+		giftWrappingType := ""
+		if len(giftWrappingIDs) > 0 {
+			giftWrappingType = "list"
+		}
 
 		// Generate product details
 		name := gofakeit.ProductName()
 		price := gofakeit.Price(10, 1000)
 		weight := gofakeit.Float64Range(0.1, 25)
-		inventory := rand.Intn(100)
+		inventory, inventoryWarning := drawInventory(gofakeit.GlobalFaker, cfg)
 
 		products[i] = Product{
 			Name:              name,
-			Type:              "physical",
-			SKU:               gofakeit.UUID(),
-			Description:       gofakeit.ProductDescription(),
+			Type:              ProductTypePhysical,
+			SKU:               skus.Next(gofakeit.GlobalFaker),
+			Description:       productDescription(cfg, name),
 			Weight:            weight,
 			Width:             gofakeit.Float64Range(1, 50),
 			Depth:             gofakeit.Float64Range(1, 50),
@@ -230,132 +959,570 @@ func generateProducts(categoryIDs, brandIDs []int) []Product {
 			CostPrice:         price * 0.6, // 60% of retail
 			RetailPrice:       price * 1.2, // 20% markup
 			SalePrice:         price * 0.9, // 10% discount
+			TaxClassID:        taxClassID,
 			Categories:        categories,
 			BrandID:           brandID,
 			InventoryLevel:    inventory,
-			InventoryWarning:  10,
-			InventoryTracking: "product",
-			IsVisible:         true,
-			IsFeatured:        rand.Float32() < 0.2, // 20% featured
+			InventoryWarning:  inventoryWarning,
+			InventoryTracking: InventoryTrackingProduct,
+			IsVisible:         chance(gofakeit.GlobalFaker, cfg.VisibleRatio),
+			IsFeatured:        chance(gofakeit.GlobalFaker, cfg.FeaturedRatio),
 			Warranty:          gofakeit.Sentence(10),
 			BinPickingNumber:  gofakeit.DigitN(6),
 			UPC:               gofakeit.DigitN(12),
 			MPN:               fmt.Sprintf("MPN-%s", gofakeit.DigitN(8)),
 			GTIN:              gofakeit.DigitN(14),
-			SearchKeywords:    gofakeit.Word() + ", " + gofakeit.Word() + ", " + gofakeit.Word(),
-			Availability:      "available",
+			SearchKeywords:    gofakeit.Word() + ", " + gofakeit.Word() + ", " + gofakeit.Word() + ", " + cfg.Marker,
+			Availability:      AvailabilityAvailable,
 			AvailabilityDesc:  "Usually ships in 1-2 business days",
+			GiftWrappingOpts:  giftWrappingType,
+			GiftWrappingList:  giftWrappingIDs,
 			SortOrder:         i,
-			Condition:         "New",
+			Condition:         ConditionNew,
 			IsConditionShown:  true,
 			OrderQuantityMin:  1,
 			OrderQuantityMax:  10,
 			PageTitle:         name,
 			MetaKeywords:      []string{gofakeit.Word(), gofakeit.Word(), gofakeit.Word()},
 			MetaDescription:   gofakeit.Paragraph(1, 2, 3, " "),
-			//CustomURL:         &CustomURL{URL: gofakeit.URL()},
-			OpenGraphType:  "product",
-			OpenGraphTitle: name,
-			OpenGraphDesc:  gofakeit.Sentence(5),
+			CustomURL:         slugs.CustomURL(name),
+			OpenGraphType:     "product",
+			OpenGraphTitle:    name,
+			OpenGraphDesc:     gofakeit.Sentence(5),
+		}
+
+		if cfg.DigitalRatio > 0 && chance(gofakeit.GlobalFaker, cfg.DigitalRatio) {
+			makeDigital(&products[i])
+		}
+
+		products[i].NormalizePrices()
+		products[i].NormalizeCategories()
+
+		// Catch bad fake data before it costs an API round trip
+		if err := products[i].Validate(); err != nil {
+			log.Printf("Generated product failed validation: %v", err)
+		}
+		for _, warning := range products[i].Warnings() {
+			log.Printf("Generated product %q: %s", name, warning)
+		}
+	}
+
+	if cfg.ShuffleSortOrder {
+		sortOrders := make([]int, len(products))
+		for i := range sortOrders {
+			sortOrders[i] = i
+		}
+		gofakeit.ShuffleInts(sortOrders)
+		for i := range products {
+			products[i].SortOrder = sortOrders[i]
 		}
 	}
 
 	return products
 }
 
-func createProducts(ctx context.Context, client *Client, products []Product) ([]int, error) {
+// makeDigital turns a generated physical product into a download: nothing
+// to ship, wrap or keep in stock.
+func makeDigital(product *Product) {
+	product.Type = ProductTypeDigital
+
+	// The create endpoint requires a weight of every product and omitempty
+	// would drop a zero one, so keep a nominal weight checkout ignores
+	product.Weight = 0.01
+	product.Width, product.Depth, product.Height = 0, 0, 0
+	product.IsFreeShipping = false
+	product.FixedCostShipping = 0
+
+	product.InventoryTracking = InventoryTrackingNone
+	product.InventoryLevel, product.InventoryWarning = 0, 0
+	product.BinPickingNumber = ""
+
+	product.GiftWrappingOpts = "none"
+	product.GiftWrappingList = nil
+
+	product.AvailabilityDesc = "Available to download immediately after purchase"
+}
+
+// createProducts returns the IDs of products created by this run or a
+// checkpointed earlier one, whose enrichment may still be outstanding.
+// Products that merely already existed in the store are only counted as
+// skipped.
+func createProducts(ctx context.Context, client *Client, cfg *Config, checkpoint *Checkpoint, products []Product) ([]int, int, error) {
 	productIDs := make([]int, 0, len(products))
+	skipped := 0
+	var errs []error
 
 	for _, product := range products {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		if id, ok := checkpoint.Product(product.SKU); ok {
+			productIDs = append(productIDs, id)
+			continue
+		}
+
+		if cfg.SkipExisting && product.SKU != "" {
+			existing, err := client.Products.GetBySKUContext(ctx, product.SKU)
+			if err == nil {
+				skipped++
+				log.Printf("Skipped existing product: %s (ID: %d)", product.Name, existing.Data.ID)
+				continue
+			}
+			if !IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to look up product %q: %w", product.Name, err))
+				if IsUnauthorized(err) {
+					break
+				}
+				continue
+			}
+		}
+
 		response, err := client.Products.CreateContext(ctx, &product)
 		if err != nil {
-			return productIDs, fmt.Errorf("failed to create product: %v", err)
+			errs = append(errs, fmt.Errorf("failed to create product %q: %w", product.Name, err))
+			if IsUnauthorized(err) {
+				break
+			}
+			continue
 		}
 		productIDs = append(productIDs, response.Data.ID)
+		checkpoint.RecordProduct(product.SKU, response.Data.ID)
 		log.Printf("Created product: %s (ID: %d)", product.Name, response.Data.ID)
 	}
 
-	return productIDs, nil
+	return productIDs, skipped, errors.Join(errs...)
 }
 
-func addCustomFields(ctx context.Context, client *Client, productID int) error {
-	for i := 0; i < NumCustomFields; i++ {
-		field := &CustomField{
-			Name:  gofakeit.Word() + " Info",
-			Value: gofakeit.Sentence(5),
+// assignCategorySortOrders orders each category's page: products whose
+// primary category it is come first, featured ones ahead, then products
+// listing it as a secondary category, each group in generation order. The
+// order depends only on the generated products, so a fixed seed always
+// produces the same pages. Products reused with -skip-existing are left
+// where they are.
+func assignCategorySortOrders(ctx context.Context, client *Client, checkpoint *Checkpoint, products []Product) error {
+	type member struct {
+		productID int
+		rank      int
+	}
+
+	members := make(map[int][]member)
+	for i, product := range products {
+		productID, ok := checkpoint.Product(product.SKU)
+		if !ok {
+			continue
 		}
+		for j, categoryID := range product.Categories {
+			rank := 2
+			if j == 0 && product.IsFeatured {
+				rank = 0
+			} else if j == 0 {
+				rank = 1
+			}
+			members[categoryID] = append(members[categoryID], member{productID, rank*len(products) + i})
+		}
+	}
 
-		_, err := client.CustomFields.CreateContext(ctx, productID, field)
-		if err != nil {
-			return fmt.Errorf("failed to create custom field: %v", err)
+	categoryIDs := make([]int, 0, len(members))
+	for categoryID := range members {
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+	sort.Ints(categoryIDs)
+
+	var errs []error
+	for _, categoryID := range categoryIDs {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
 		}
+
+		inCategory := members[categoryID]
+		sort.Slice(inCategory, func(a, b int) bool { return inCategory[a].rank < inCategory[b].rank })
+
+		sortOrders := make([]ProductSortOrder, len(inCategory))
+		for i, m := range inCategory {
+			sortOrders[i] = ProductSortOrder{ProductID: m.productID, SortOrder: i}
+		}
+
+		if err := client.Categories.SetProductSortOrderContext(ctx, categoryID, sortOrders); err != nil {
+			errs = append(errs, fmt.Errorf("failed to set product sort order for category %d: %w", categoryID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Enrichment steps, used as the keys of ProductResult.Errors.
+const (
+	StepCustomFields = "custom_fields"
+	StepMarker       = "marker"
+	StepImages       = "images"
+	StepVideos       = "videos"
+	StepVariants     = "variants"
+	StepComplexRules = "complex_rules"
+	StepModifiers    = "modifiers"
+	StepReviews      = "reviews"
+	StepBulkPricing  = "bulk_pricing"
+
+	// StepNotStarted marks a product the run was cancelled before reaching.
+	StepNotStarted = "not_started"
+)
+
+// ProductResult records which enrichment steps failed for a product and the
+// IDs of the sub-resources each step created, both keyed by step. Errors is
+// empty when every step succeeded or the checkpoint already had the product
+// enriched, in which case Created is empty too.
+type ProductResult struct {
+	ProductID int
+	Errors    map[string]error
+	Created   map[string][]int
+}
+
+// enrichProducts adds the secondary data to each product, returning one
+// result per product in the order of productIDs. Rejected credentials stop
+// the remaining products, which are left for -resume.
+func enrichProducts(ctx context.Context, client *Client, cfg *Config, checkpoint *Checkpoint, images *imagePool, skus *skuPool, productIDs []int) []ProductResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]ProductResult, len(productIDs))
+	indexes := make([]int, len(productIDs))
+	for i, productID := range productIDs {
+		indexes[i] = i
+		results[i] = ProductResult{ProductID: productID, Errors: make(map[string]error), Created: make(map[string][]int)}
 	}
 
+	errs := ForEach(ctx, indexes, cfg.Concurrency, func(ctx context.Context, i int) error {
+		productID := productIDs[i]
+		if checkpoint.IsEnriched(productID) {
+			return nil
+		}
+
+		// A product left part way by an earlier run starts over, as its
+		// steps would otherwise add their sub-resources a second time
+		if checkpoint.IsPartlyEnriched(productID) {
+			if err := clearEnrichment(ctx, client, cfg, productID); err != nil {
+				log.Printf("Failed to clear partial enrichment of product %d: %v", productID, err)
+				results[i].Errors[StepNotStarted] = err
+				if IsUnauthorized(err) {
+					cancel()
+				}
+				return nil
+			}
+		}
+		checkpoint.RecordStarted(productID)
+
+		enrichProduct(ctx, client, cfg, productFaker(cfg.Seed, i), images, skus, &results[i])
+		for _, err := range results[i].Errors {
+			if IsUnauthorized(err) {
+				cancel()
+			}
+		}
+
+		// A cancelled run may have skipped steps, so leave it for -resume
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		checkpoint.RecordEnriched(productID)
+
+		return nil
+	})
+
+	for i, err := range errs {
+		if len(results[i].Errors) == 0 {
+			results[i].Errors[StepNotStarted] = err
+		}
+	}
+
+	return results
+}
+
+// productFaker returns the generator for enriching the product at index of
+// the run. Workers reach products in whatever order they are scheduled, so
+// each product draws from its own stream of the seed rather than the shared
+// one, and the same seed still enriches each product the same way.
+func productFaker(seed int64, index int) *gofakeit.Faker {
+	return gofakeit.NewFaker(rand.NewPCG(uint64(seed), uint64(index)), false)
+}
+
+// clearEnrichment deletes what the enrichment steps add to productID, so
+// they can run again without duplicating it. Deleting the options takes
+// their variants with them, and custom fields need no clearing as that
+// step replaces them.
+func clearEnrichment(ctx context.Context, client *Client, cfg *Config, productID int) error {
+	errs := []error{
+		client.ProductImages.DeleteAllContext(ctx, productID),
+		client.ProductVideos.DeleteAllContext(ctx, productID),
+		client.ComplexRules.DeleteAllContext(ctx, productID),
+		client.Options.DeleteAllContext(ctx, productID),
+		client.Modifiers.DeleteAllContext(ctx, productID),
+		client.Reviews.DeleteAllContext(ctx, productID),
+		client.BulkPricingRules.DeleteAllContext(ctx, productID),
+		deleteMarkerMetafield(ctx, client, cfg, productID),
+	}
+	return errors.Join(errs...)
+}
+
+// deleteMarkerMetafield removes the metafield addMarkerMetafield creates.
+func deleteMarkerMetafield(ctx context.Context, client *Client, cfg *Config, productID int) error {
+	metafields, err := client.Metafields.ListContext(ctx, "products", productID, &QueryParams{Limit: maxPageLimit})
+	if err != nil {
+		return fmt.Errorf("failed to list metafields: %w", err)
+	}
+	for _, metafield := range metafields.Data {
+		if metafield.Namespace == cfg.Marker && metafield.Key == "seed" {
+			if err := client.Metafields.DeleteContext(ctx, "products", productID, metafield.ID); err != nil {
+				return fmt.Errorf("failed to delete marker metafield: %w", err)
+			}
+		}
+	}
 	return nil
 }
 
-func addProductImages(ctx context.Context, client *Client, productID int) error {
-	numImages := rand.Intn(MaxImages) + 1
+// enrichProduct runs each enrichment step for result.ProductID, recording
+// what each created and any failure in result. A product whose custom
+// fields fail is left alone, as every later step would likely fail the same
+// way.
+func enrichProduct(ctx context.Context, client *Client, cfg *Config, faker *gofakeit.Faker, images *imagePool, skus *skuPool, result *ProductResult) {
+	productID := result.ProductID
+	record := func(step string, ids []int, err error) {
+		if len(ids) > 0 {
+			result.Created[step] = ids
+		}
+		if err != nil {
+			log.Printf("Failed to add %s for product %d: %v", strings.ReplaceAll(step, "_", " "), productID, err)
+			result.Errors[step] = err
+		}
+	}
+
+	// Add custom fields, giving up on the product if that fails
+	if !cfg.NoCustomFields {
+		ids, err := addCustomFields(ctx, client, faker, productID)
+		record(StepCustomFields, ids, err)
+		if err != nil {
+			return
+		}
+	}
+
+	// Tag the product for later teardown
+	ids, err := addMarkerMetafield(ctx, client, cfg, productID)
+	record(StepMarker, ids, err)
+
+	// Add images
+	if !cfg.NoImages {
+		ids, err = addProductImages(ctx, client, faker, images, productID)
+		record(StepImages, ids, err)
+	}
+
+	// Add videos
+	if !cfg.NoVideos {
+		ids, err = addProductVideos(ctx, client, faker, productID)
+		record(StepVideos, ids, err)
+	}
+
+	// Add options and variants, then complex rules over the options just
+	// created
+	if !cfg.NoVariants {
+		options, variantIDs, err := addOptionsAndVariants(ctx, client, cfg, faker, images, skus, productID)
+		record(StepVariants, variantIDs, err)
+
+		ids, err = addComplexRules(ctx, client, cfg, faker, productID, options)
+		record(StepComplexRules, ids, err)
+	}
+
+	// Add modifiers
+	ids, err = addProductModifiers(ctx, client, cfg, faker, productID)
+	record(StepModifiers, ids, err)
+
+	// Add reviews
+	if !cfg.NoReviews {
+		ids, err = addProductReviews(ctx, client, cfg, faker, productID)
+		record(StepReviews, ids, err)
+	}
+
+	// Add bulk pricing rules
+	if !cfg.NoBulkPricing {
+		ids, err = addBulkPricingRules(ctx, client, cfg, faker, productID)
+		record(StepBulkPricing, ids, err)
+	}
+}
+
+// summarizeResults counts incomplete products per failed step, e.g.
+// "2 images, 1 variants", or returns "" when every product is complete.
+func summarizeResults(results []ProductResult) string {
+	counts := make(map[string]int)
+	for _, result := range results {
+		for step := range result.Errors {
+			counts[step]++
+		}
+	}
+	return formatStepCounts(counts)
+}
+
+// summarizeCreated counts the sub-resources created per step, e.g.
+// "12 images, 5 variants", or returns "" when nothing was created.
+func summarizeCreated(results []ProductResult) string {
+	counts := make(map[string]int)
+	for _, result := range results {
+		for step, ids := range result.Created {
+			counts[step] += len(ids)
+		}
+	}
+	return formatStepCounts(counts)
+}
+
+func formatStepCounts(counts map[string]int) string {
+	steps := make([]string, 0, len(counts))
+	for step := range counts {
+		steps = append(steps, step)
+	}
+	sort.Strings(steps)
+
+	parts := make([]string, len(steps))
+	for i, step := range steps {
+		parts[i] = fmt.Sprintf("%d %s", counts[step], step)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// addMarkerMetafield records the run's seed under the marker namespace, so
+// generated products can be found through the metafields API as well.
+func addMarkerMetafield(ctx context.Context, client *Client, cfg *Config, productID int) ([]int, error) {
+	metafield := &Metafield{
+		Namespace:   cfg.Marker,
+		Key:         "seed",
+		Value:       strconv.FormatInt(cfg.Seed, 10),
+		Permission:  MetafieldPermissionAppOnly,
+		Description: "Created by the storefront generator",
+	}
+	if err := metafield.Validate(); err != nil {
+		return nil, err
+	}
+
+	response, err := client.Metafields.CreateContext(ctx, "products", productID, metafield)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create marker metafield: %w", err)
+	}
+
+	return []int{response.Data.ID}, nil
+}
+
+func addCustomFields(ctx context.Context, client *Client, faker *gofakeit.Faker, productID int) ([]int, error) {
+	fields := make([]CustomField, 0, NumCustomFields)
+	for i := 0; i < NumCustomFields; i++ {
+		fields = append(fields, CustomField{
+			Name:  faker.Word() + " Info",
+			Value: faker.Sentence(5),
+		})
+	}
+
+	// Replacing rather than appending keeps re-runs from piling up fields
+	created, err := client.CustomFields.ReplaceAllContext(ctx, productID, fields)
+
+	fieldIDs := make([]int, 0, len(created))
+	for _, field := range created {
+		fieldIDs = append(fieldIDs, field.ID)
+	}
+	if err != nil {
+		return fieldIDs, fmt.Errorf("failed to replace custom fields: %w", err)
+	}
+
+	return fieldIDs, nil
+}
+
+func addProductImages(ctx context.Context, client *Client, faker *gofakeit.Faker, images *imagePool, productID int) ([]int, error) {
+	numImages := faker.IntN(MaxImages) + 1
+	thumbnail := faker.IntN(numImages)
+	imageIDs := make([]int, 0, numImages)
 	for i := 0; i < numImages; i++ {
 		image := &ProductImage{
-			ImageFile:   "https://images.pexels.com/photos/45201/kitty-cat-kitten-pet-45201.jpeg",
-			IsThumbnail: i == 0,
+			IsThumbnail: i == thumbnail,
 			SortOrder:   i,
-			Description: gofakeit.Sentence(5),
+			Description: faker.Sentence(5),
+		}
+
+		source := images.Next()
+		if source.Path == "" {
+			image.ImageURL = source.URL
+			response, err := client.ProductImages.CreateContext(ctx, productID, image)
+			if err != nil {
+				return imageIDs, fmt.Errorf("failed to create product image: %w", err)
+			}
+			imageIDs = append(imageIDs, response.Data.ID)
+			continue
 		}
 
-		_, err := client.ProductImages.CreateContext(ctx, productID, image)
+		imageID, err := uploadProductImage(ctx, client, productID, source.Path, image)
 		if err != nil {
-			return fmt.Errorf("failed to create product image: %v", err)
+			return imageIDs, fmt.Errorf("failed to upload product image %s: %w", source.Path, err)
 		}
+		imageIDs = append(imageIDs, imageID)
 	}
 
-	return nil
+	return imageIDs, nil
+}
+
+func uploadProductImage(ctx context.Context, client *Client, productID int, path string, image *ProductImage) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	response, err := client.ProductImages.UploadContext(ctx, productID, filepath.Base(path), file, image)
+	if err != nil {
+		return 0, err
+	}
+	return response.Data.ID, nil
 }
 
-func addProductVideos(ctx context.Context, client *Client, productID int) error {
-	numVideos := rand.Intn(MaxVideos + 1)
+func addProductVideos(ctx context.Context, client *Client, faker *gofakeit.Faker, productID int) ([]int, error) {
+	numVideos := faker.IntN(MaxVideos + 1)
 
 	if numVideos == 0 {
-		return nil
+		return nil, nil
 	}
 
+	videoIDs := make([]int, 0, numVideos)
 	for i := 0; i < numVideos; i++ {
-		videoID := gofakeit.UUID() // Using UUID as dummy YouTube video ID
+		videoID := faker.UUID() // Using UUID as dummy YouTube video ID
 
 		video := &ProductVideo{
-			Title:       gofakeit.ProductName() + " Video",
-			Description: gofakeit.Sentence(10),
+			Title:       faker.ProductName() + " Video",
+			Description: faker.Sentence(10),
 			SortOrder:   i,
 			Type:        "youtube",
 			VideoID:     videoID,
 		}
 
-		_, err := client.ProductVideos.CreateContext(ctx, productID, video)
+		response, err := client.ProductVideos.CreateContext(ctx, productID, video)
 		if err != nil {
-			return fmt.Errorf("failed to create product video: %v", err)
+			return videoIDs, fmt.Errorf("failed to create product video: %w", err)
 		}
+		videoIDs = append(videoIDs, response.Data.ID)
 	}
 
-	return nil
+	return videoIDs, nil
 }
 
-func addOptionsAndVariants(ctx context.Context, client *Client, productID int) error {
-	numOptions := rand.Intn(MaxOptions + 1)
+// addOptionsAndVariants returns the options it created, each with its
+// option values and their IDs, and the IDs of the variants created.
+func addOptionsAndVariants(ctx context.Context, client *Client, cfg *Config, faker *gofakeit.Faker, images *imagePool, skus *skuPool, productID int) ([]ProductOption, []int, error) {
+	numOptions := faker.IntN(MaxOptions + 1)
 
 	if numOptions == 0 {
-		return nil
+		return nil, nil, nil
 	}
 
 	optionTypes := []string{"dropdown", "radio", "checkbox", "swatch"}
-	optionNames := []string{"Color", "Size", "Material", "Style"}
 
 	optionIDs := make([]int, 0, numOptions)
 	optionValueMap := make(map[int][]OptionValue)
+	options := make([]ProductOption, 0, numOptions)
 
 	// Create options
 	for i := 0; i < numOptions; i++ {
-		optionType := optionTypes[rand.Intn(len(optionTypes))]
+		optionType := optionTypes[faker.IntN(len(optionTypes))]
 		optionName := optionNames[i%len(optionNames)]
 
 		option := &ProductOption{
@@ -365,32 +1532,17 @@ func addOptionsAndVariants(ctx context.Context, client *Client, productID int) e
 
 		optionResp, err := client.Options.CreateContext(ctx, productID, option)
 		if err != nil {
-			return fmt.Errorf("failed to create product option: %v", err)
+			return options, nil, fmt.Errorf("failed to create product option: %w", err)
 		}
 
 		optionID := optionResp.Data.ID
 		optionIDs = append(optionIDs, optionID)
 
 		// Create option values
-		numValues := rand.Intn(3) + 2 // 2-4 values
-		values := make([]OptionValue, 0, numValues)
-
-		for j := 0; j < numValues; j++ {
-			var value string
-
-			switch optionName {
-			case "Color":
-				value = gofakeit.Color()
-			case "Size":
-				sizes := []string{"Small", "Medium", "Large", "X-Large", "XX-Large"}
-				value = sizes[j%len(sizes)]
-			case "Material":
-				materials := []string{"Cotton", "Polyester", "Wool", "Leather", "Silk"}
-				value = materials[j%len(materials)]
-			default:
-				value = gofakeit.Word()
-			}
+		labels := optionValueLabels(faker, cfg, optionName)
+		values := make([]OptionValue, 0, len(labels))
 
+		for j, value := range labels {
 			optionValue := OptionValue{
 				OptionID:  optionID,
 				Label:     value,
@@ -399,9 +1551,16 @@ func addOptionsAndVariants(ctx context.Context, client *Client, productID int) e
 				IsDefault: j == 0,
 			}
 
+			// Swatches render from value_data, not the label
+			if optionType == "swatch" {
+				optionValue.ValueData = map[string]interface{}{
+					"colors": []string{faker.HexColor()},
+				}
+			}
+
 			valueResp, err := client.Options.CreateOptionValueContext(ctx, productID, optionID, &optionValue)
 			if err != nil {
-				return fmt.Errorf("failed to create option value: %v", err)
+				return options, nil, fmt.Errorf("failed to create option value: %w", err)
 			}
 
 			optionValue.ID = valueResp.Data.ID
@@ -409,11 +1568,17 @@ func addOptionsAndVariants(ctx context.Context, client *Client, productID int) e
 		}
 
 		optionValueMap[optionID] = values
+
+		option.ID = optionID
+		option.OptionValues = values
+		options = append(options, *option)
 	}
 
 	// Create variants if there are options
+	var variantIDs []int
 	if len(optionIDs) > 0 {
-		numVariants := rand.Intn(MaxVariants) + 1
+		numVariants := faker.IntN(MaxVariants) + 1
+		variants := make([]Variant, 0, numVariants)
 
 		for i := 0; i < numVariants; i++ {
 			// Create variant options
@@ -421,65 +1586,287 @@ func addOptionsAndVariants(ctx context.Context, client *Client, productID int) e
 
 			for _, optionID := range optionIDs {
 				values := optionValueMap[optionID]
-				valueIndex := rand.Intn(len(values))
+				valueIndex := faker.IntN(len(values))
 				variantOptions = append(variantOptions, values[valueIndex])
 			}
 
-			// Create variant
-			variant := &Variant{
-				SKU:                   gofakeit.UUID(),
-				Price:                 gofakeit.Price(10, 1000),
-				Weight:                gofakeit.Float64Range(0.1, 25),
-				Depth:                 gofakeit.Float64Range(1, 50),
-				Height:                gofakeit.Float64Range(1, 50),
-				Width:                 gofakeit.Float64Range(1, 50),
-				InventoryLevel:        rand.Intn(100),
-				InventoryWarningLevel: 10,
+			inventory, inventoryWarning := drawInventory(faker, cfg)
+			variants = append(variants, Variant{
+				SKU:                   skus.Next(faker),
+				Price:                 faker.Price(10, 1000),
+				Weight:                faker.Float64Range(0.1, 25),
+				Depth:                 faker.Float64Range(1, 50),
+				Height:                faker.Float64Range(1, 50),
+				Width:                 faker.Float64Range(1, 50),
+				InventoryLevel:        inventory,
+				InventoryWarningLevel: inventoryWarning,
 				OptionValues:          variantOptions,
+			})
+		}
+
+		// The options were just created, so checking against them needs no
+		// request but still catches values mixed up between options
+		if err := ValidateVariantOptionValues(options, variants); err != nil {
+			return options, nil, fmt.Errorf("invalid variants: %w", err)
+		}
+
+		// Random picks can repeat a combination, which the API rejects;
+		// the rest of the batch is still created
+		created, err := client.Variants.CreateBatchContext(ctx, productID, variants)
+		if err != nil {
+			err = fmt.Errorf("failed to create variants: %w", err)
+		}
+		for _, variant := range created {
+			if variant.ID != 0 {
+				variantIDs = append(variantIDs, variant.ID)
 			}
+		}
 
-			_, err := client.Variants.CreateContext(ctx, productID, variant)
-			if err != nil {
-				return fmt.Errorf("failed to create variant: %v", err)
+		if cfg.VariantImages {
+			if imageErr := addVariantImages(ctx, client, images, productID, options, created); imageErr != nil {
+				err = errors.Join(err, imageErr)
 			}
 		}
+
+		if err != nil {
+			return options, variantIDs, err
+		}
 	}
 
-	return nil
+	return options, variantIDs, nil
 }
 
-func addProductReviews(ctx context.Context, client *Client, productID int) error {
-	numReviews := rand.Intn(MaxReviews + 1)
+func addProductReviews(ctx context.Context, client *Client, cfg *Config, faker *gofakeit.Faker, productID int) ([]int, error) {
+	numReviews := faker.IntN(MaxReviews + 1)
 
 	if numReviews == 0 {
-		return nil
+		return nil, nil
 	}
 
+	dates := make([]time.Time, 0, numReviews)
+	if cfg.ReviewWindow > 0 {
+		now := time.Now()
+		for i := 0; i < numReviews; i++ {
+			dates = append(dates, faker.DateRange(now.Add(-cfg.ReviewWindow), now))
+		}
+		sort.Slice(dates, func(a, b int) bool { return dates[a].Before(dates[b]) })
+	}
+
+	reviewIDs := make([]int, 0, numReviews)
 	for i := 0; i < numReviews; i++ {
-		rating := rand.Intn(4) + 2 // Ratings 2-5
+		rating := faker.IntN(4) + 2 // Ratings 2-5
 
 		review := &Review{
-			Title:  gofakeit.Sentence(3),
-			Text:   gofakeit.Paragraph(1, 3, 5, " "),
-			Status: "approved",
+			Title:  faker.Sentence(3),
+			Text:   faker.Paragraph(1, 3, 5, " "),
+			Status: ReviewStatusApproved,
 			Rating: rating,
-			Name:   gofakeit.Name(),
-			Email:  gofakeit.Email(),
+			Name:   faker.Name(),
+			Email:  faker.Email(),
+		}
+		if i < len(dates) {
+			review.DateCreated = dates[i].UTC().Format(time.RFC3339)
+		}
+
+		response, err := client.Reviews.CreateContext(ctx, productID, review)
+		if err != nil {
+			return reviewIDs, fmt.Errorf("failed to create review: %w", err)
+		}
+		reviewIDs = append(reviewIDs, response.Data.ID)
+	}
+
+	return reviewIDs, nil
+}
+
+func addProductModifiers(ctx context.Context, client *Client, cfg *Config, faker *gofakeit.Faker, productID int) ([]int, error) {
+	// Only add modifiers to some products
+	if !chance(faker, cfg.ModifierRatio) {
+		return nil, nil
+	}
+
+	modifiers := []Modifier{
+		{
+			Name:        "Gift Box",
+			DisplayName: "Gift Box",
+			Type:        "dropdown",
+			OptionValues: []OptionValue{
+				{Label: "None", SortOrder: 0, IsDefault: true},
+				{Label: "Standard", SortOrder: 1},
+				{Label: "Premium", SortOrder: 2},
+			},
+		},
+		{
+			Name:        "Engraving",
+			DisplayName: "Engraving Text",
+			Type:        "text",
+			Config: &ModifierConfig{
+				TextMinLength:  1,
+				TextMaxLength:  faker.IntN(40) + 10,
+				TextCharsLimit: true,
+			},
+		},
+		{
+			Name:        "Gift Receipt",
+			DisplayName: "Gift Receipt",
+			Type:        "checkbox",
+			Config: &ModifierConfig{
+				CheckboxLabel: "Include a gift receipt",
+			},
+		},
+		{
+			Name:        "Artwork",
+			DisplayName: "Upload Artwork",
+			Type:        "file",
+			Config: &ModifierConfig{
+				FileTypes:   []string{"images", "documents"},
+				FileMaxSize: 5,
+			},
+		},
+	}
+
+	// Pick a random subset
+	faker.ShuffleAnySlice(modifiers)
+	numModifiers := faker.IntN(MaxModifiers) + 1
+	if numModifiers > len(modifiers) {
+		numModifiers = len(modifiers)
+	}
+
+	modifierIDs := make([]int, 0, numModifiers)
+	for i := range modifiers[:numModifiers] {
+		modifier := &modifiers[i]
+		modifier.Required = modifier.Type == "dropdown"
+
+		values, err := NormalizeModifierValues(modifier.Type, modifier.OptionValues)
+		if err != nil {
+			return modifierIDs, fmt.Errorf("invalid %s modifier: %w", modifier.Type, err)
 		}
+		modifier.OptionValues = values
 
-		_, err := client.Reviews.CreateContext(ctx, productID, review)
+		response, err := client.Modifiers.CreateContext(ctx, productID, modifier)
 		if err != nil {
-			return fmt.Errorf("failed to create review: %v", err)
+			return modifierIDs, fmt.Errorf("failed to create %s modifier: %w", modifier.Type, err)
+		}
+		modifierIDs = append(modifierIDs, response.Data.ID)
+	}
+
+	return modifierIDs, nil
+}
+
+// optionValueLabels returns the values for an option named name: the first
+// ones from its vocabulary, configured or built in, or else random colors
+// or words. Values are unique within the option and the first becomes its
+// default.
+func optionValueLabels(faker *gofakeit.Faker, cfg *Config, name string) []string {
+	n := cfg.MinOptionValues + faker.IntN(cfg.MaxOptionValues-cfg.MinOptionValues+1)
+
+	vocabulary, ok := cfg.OptionValues[name]
+	if !ok {
+		vocabulary, ok = defaultOptionValues[name]
+	}
+	if ok {
+		return vocabulary[:min(n, len(vocabulary))]
+	}
+
+	draw := faker.Word
+	if name == "Color" {
+		draw = faker.Color
+	}
+
+	labels := make([]string, 0, n)
+	for len(labels) < n {
+		label := draw()
+		for attempt := 0; slices.Contains(labels, label) && attempt < 10; attempt++ {
+			label = draw()
+		}
+		if slices.Contains(labels, label) {
+			label = fmt.Sprintf("%s %d", label, len(labels)+1)
+		}
+		labels = append(labels, label)
+	}
+
+	return labels
+}
+
+// addVariantImages assigns each created variant an image. Variants sharing
+// a color value share an image, so the color swatch previews it; without a
+// color option every variant gets its own.
+func addVariantImages(ctx context.Context, client *Client, images *imagePool, productID int, options []ProductOption, variants []Variant) error {
+	colorOptionID := 0
+	for _, option := range options {
+		if option.DisplayName == "Color" {
+			colorOptionID = option.ID
+		}
+	}
+
+	colorImages := make(map[int]string)
+	for _, variant := range variants {
+		if variant.ID == 0 {
+			continue // not created
+		}
+
+		imageURL := ""
+		for _, value := range variant.OptionValues {
+			if value.OptionID == colorOptionID && colorOptionID != 0 {
+				if _, ok := colorImages[value.ID]; !ok {
+					colorImages[value.ID] = images.NextURL()
+				}
+				imageURL = colorImages[value.ID]
+			}
+		}
+		if imageURL == "" {
+			imageURL = images.NextURL()
+		}
+
+		if err := client.Variants.CreateImageContext(ctx, productID, variant.ID, imageURL); err != nil {
+			return fmt.Errorf("failed to set image for variant %d: %w", variant.ID, err)
 		}
 	}
 
 	return nil
 }
 
-func addBulkPricingRules(ctx context.Context, client *Client, productID int) error {
-	// Only add bulk pricing rules to 30% of products
-	if rand.Float32() > 0.3 {
-		return nil
+// addComplexRules creates a rule for one combination of values across two
+// options, either adjusting its price or disabling its purchase.
+func addComplexRules(ctx context.Context, client *Client, cfg *Config, faker *gofakeit.Faker, productID int, options []ProductOption) ([]int, error) {
+	if len(options) < 2 || !chance(faker, cfg.ComplexRuleRatio) {
+		return nil, nil
+	}
+
+	conditions := make([]RuleCondition, 0, 2)
+	for _, option := range options[:2] {
+		value := option.OptionValues[faker.IntN(len(option.OptionValues))]
+		conditions = append(conditions, RuleCondition{
+			OptionID: option.ID,
+			ValueID:  value.ID,
+		})
+	}
+
+	rule := &ComplexRule{
+		Enabled:    true,
+		Conditions: conditions,
+	}
+	if chance(faker, 0.5) {
+		rule.Adjusters = &RuleAdjusters{
+			Type:   "relative",
+			Amount: faker.Price(1, 50),
+		}
+	} else {
+		rule.Purchasing = true
+		rule.PurchasingMsg = "This combination is currently unavailable"
+	}
+
+	response, err := client.ComplexRules.CreateContext(ctx, productID, rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create complex rule: %w", err)
+	}
+
+	return []int{response.Data.ID}, nil
+}
+
+func addBulkPricingRules(ctx context.Context, client *Client, cfg *Config, faker *gofakeit.Faker, productID int) ([]int, error) {
+	// Only add bulk pricing rules to some products
+	if !chance(faker, cfg.BulkPricingRatio) {
+		return nil, nil
 	}
 
 	// Define some tiers
@@ -493,6 +1880,7 @@ func addBulkPricingRules(ctx context.Context, client *Client, productID int) err
 		{20, 0, 15}, // 0 max means unlimited
 	}
 
+	ruleIDs := make([]int, 0, len(tiers))
 	for _, tier := range tiers {
 		rule := &PricingRule{
 			QuantityMin: tier.Min,
@@ -501,11 +1889,86 @@ func addBulkPricingRules(ctx context.Context, client *Client, productID int) err
 			Amount:      tier.Amount,
 		}
 
-		_, err := client.BulkPricingRules.CreateContext(ctx, productID, rule)
+		response, err := client.BulkPricingRules.CreateContext(ctx, productID, rule)
 		if err != nil {
-			return fmt.Errorf("failed to create bulk pricing rule: %v", err)
+			return ruleIDs, fmt.Errorf("failed to create bulk pricing rule: %w", err)
 		}
+		ruleIDs = append(ruleIDs, response.Data.ID)
 	}
 
-	return nil
+	return ruleIDs, nil
+}
+
+// drawInventory returns an inventory level and warning level following
+// cfg.InventoryDistribution.
+func drawInventory(faker *gofakeit.Faker, cfg *Config) (int, int) {
+	if cfg.InventoryDistribution != InventoryLongTail {
+		inventory := faker.IntN(100)
+		if chance(faker, cfg.OutOfStockRatio) {
+			inventory = 0
+		}
+		return inventory, 10
+	}
+
+	// Most stock sits in a mid range with a few deep items, and the warning
+	// level is a fifth of what is normally held
+	typical := faker.IntN(80) + 20
+	if chance(faker, 0.1) {
+		typical = faker.IntN(800) + 200
+	}
+	warning := typical / 5
+
+	roll := faker.Float64()
+	switch {
+	case roll < cfg.OutOfStockRatio:
+		return 0, warning
+	case roll < cfg.OutOfStockRatio+cfg.LowStockRatio:
+		return faker.IntN(warning) + 1, warning
+	default:
+		return typical, warning
+	}
+}
+
+// stopIfCancelled saves the checkpoint and exits once ctx has been
+// cancelled by an interrupt.
+func stopIfCancelled(ctx context.Context, checkpoint *Checkpoint) {
+	if ctx.Err() == nil {
+		return
+	}
+
+	log.Println("Interrupted, saving checkpoint")
+	if err := checkpoint.Save(); err != nil {
+		log.Printf("Failed to save checkpoint: %v", err)
+	}
+	os.Exit(130)
+}
+
+// stopIfUnauthorized exits once the store has rejected the credentials, as
+// every later request would fail the same way.
+func stopIfUnauthorized(failures *failureTally, checkpoint *Checkpoint) {
+	if failures.Auth == 0 {
+		return
+	}
+
+	log.Printf("Stopping, the store rejected the API credentials. Failures: %s", failures)
+	if err := checkpoint.Save(); err != nil {
+		log.Printf("Failed to save checkpoint: %v", err)
+	}
+	os.Exit(1)
+}
+
+func nonZero(ids []int) []int {
+	filtered := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered
+}
+
+// chance reports true with probability ratio, drawing from faker so runs
+// are reproducible.
+func chance(faker *gofakeit.Faker, ratio float64) bool {
+	return faker.Float64() < ratio
 }
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+func TestCreateCategoriesUsesAssignedParentIDs(t *testing.T) {
+	// IDs the store assigns, deliberately unlike the generation indexes
+	nextID := 500
+	parentIDs := make(map[string]int)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			return
+		}
+
+		var category Category
+		if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+			t.Fatal(err)
+		}
+		parentIDs[category.Name] = category.ParentID
+
+		category.ID = nextID
+		nextID += 10
+		writeJSON(t, w, http.StatusOK, CategoryResponse{Data: category})
+	}))
+
+	categories := []Category{{Name: "Root"}, {Name: "Child A"}, {Name: "Child B"}, {Name: "Grandchild"}}
+	parents := []int{-1, 0, 0, 1}
+
+	categoryIDs, skipped, err := createCategories(t.Context(), client, &Config{}, NewCheckpoint("", 1), categories, parents)
+	if err != nil {
+		t.Fatalf("createCategories() error = %v", err)
+	}
+	if skipped != 0 {
+		t.Errorf("createCategories() skipped %d, want 0", skipped)
+	}
+
+	wantIDs := []int{500, 510, 520, 530}
+	for i, id := range wantIDs {
+		if categoryIDs[i] != id {
+			t.Errorf("category %d has ID %d, want %d", i, categoryIDs[i], id)
+		}
+	}
+
+	wantParents := map[string]int{"Root": 0, "Child A": 500, "Child B": 500, "Grandchild": 510}
+	for name, parentID := range wantParents {
+		if parentIDs[name] != parentID {
+			t.Errorf("%s was created under parent %d, want %d", name, parentIDs[name], parentID)
+		}
+	}
+}
+
+func TestCreateCategoriesRejectsParentsGeneratedLater(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, CategoryResponse{Data: Category{ID: 1}})
+	}))
+
+	categories := []Category{{Name: "Orphan"}, {Name: "Parent"}}
+	categoryIDs, _, err := createCategories(t.Context(), client, &Config{}, NewCheckpoint("", 1), categories, []int{1, -1})
+	if err == nil {
+		t.Fatal("createCategories() error = nil, want one for the orphan")
+	}
+	if categoryIDs[0] != 0 {
+		t.Errorf("orphan was created with ID %d", categoryIDs[0])
+	}
+}
+
+func TestValidateRequiresSeedWithSkipExisting(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"no seed", Config{SkipExisting: true}, true},
+		{"seed", Config{SkipExisting: true, Seed: 42}, false},
+		{"resume", Config{SkipExisting: true, Resume: true}, false},
+		{"not skipping", Config{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Other fields are left invalid, so look for this check alone
+			err := tt.cfg.Validate()
+			if got := err != nil && strings.Contains(err.Error(), "-skip-existing"); got != tt.wantErr {
+				t.Errorf("Validate() = %v, want a -skip-existing error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// enrichmentStore fakes the endpoints enrichment writes to, recording the
+// variant SKUs and review titles sent for each product and what was
+// deleted from it.
+type enrichmentStore struct {
+	t       *testing.T
+	mu      sync.Mutex
+	nextID  int
+	created map[int][]string
+	deleted map[int][]string
+
+	// leftImages lists an image on each product it holds, as an earlier,
+	// interrupted run would have left
+	leftImages map[int]bool
+
+	// rateLimitEvery, when positive, answers the first attempt of every
+	// nth request with a 429, so each retry succeeds
+	rateLimitEvery int
+	requests       int
+	rateLimited    map[string]bool
+}
+
+func (s *enrichmentStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	requestID := r.Header.Get(requestIDHeader)
+	limited := false
+	if _, retried := s.rateLimited[requestID]; !retried && s.rateLimitEvery > 0 {
+		s.requests++
+		limited = s.requests%s.rateLimitEvery == 0
+		s.rateLimited[requestID] = limited
+	}
+	s.mu.Unlock()
+	if limited {
+		w.Header().Set("X-Rate-Limit-Time-Reset-Ms", "1")
+		writeJSON(s.t, w, http.StatusTooManyRequests, map[string]interface{}{"status": 429, "title": "Too Many Requests"})
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/stores/store/v3/catalog/products/"), "/")
+	productID, _ := strconv.Atoi(parts[0])
+
+	switch r.Method {
+	case http.MethodGet:
+		data := []interface{}{}
+		s.mu.Lock()
+		if s.leftImages[productID] && strings.HasSuffix(r.URL.Path, "/images") {
+			data = append(data, ProductImage{ID: 7, ProductID: productID})
+		}
+		s.mu.Unlock()
+		writeJSON(s.t, w, http.StatusOK, map[string]interface{}{"data": data, "meta": pageMeta(1, 1)})
+		return
+	case http.MethodDelete:
+		s.mu.Lock()
+		s.deleted[productID] = append(s.deleted[productID], strings.Join(parts[1:], "/"))
+		if strings.HasSuffix(r.URL.Path, "/images/7") {
+			delete(s.leftImages, productID)
+		}
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var sent map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	sent["id"] = s.nextID
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/variants"):
+		s.created[productID] = append(s.created[productID], sent["sku"].(string))
+	case strings.HasSuffix(r.URL.Path, "/reviews"):
+		s.created[productID] = append(s.created[productID], sent["title"].(string))
+	}
+	s.mu.Unlock()
+
+	writeJSON(s.t, w, http.StatusOK, map[string]interface{}{"data": sent})
+}
+
+func enrichmentConfig(concurrency int) *Config {
+	return &Config{
+		Seed:             42,
+		Marker:           DefaultMarker,
+		Concurrency:      concurrency,
+		NoImages:         true,
+		MinOptionValues:  2,
+		MaxOptionValues:  4,
+		ModifierRatio:    0.5,
+		ComplexRuleRatio: 0.5,
+		BulkPricingRatio: 0.5,
+	}
+}
+
+func TestEnrichProductsIsReproducibleAcrossWorkers(t *testing.T) {
+	productIDs := make([]int, 20)
+	for i := range productIDs {
+		productIDs[i] = 1000 + i
+	}
+
+	var runs []map[int][]string
+	for _, concurrency := range []int{1, 4, 8} {
+		store := &enrichmentStore{t: t, created: make(map[int][]string), deleted: make(map[int][]string)}
+		client := newTestClient(t, store)
+
+		cfg := enrichmentConfig(concurrency)
+		results := enrichProducts(t.Context(), client, cfg, NewCheckpoint("", cfg.Seed), nil, newSKUPool(), productIDs)
+		if incomplete := summarizeResults(results); incomplete != "" {
+			t.Fatalf("enrichProducts() with concurrency %d left products incomplete: %s", concurrency, incomplete)
+		}
+		// Variants of a product are created in parallel too
+		for _, created := range store.created {
+			sort.Strings(created)
+		}
+		runs = append(runs, store.created)
+	}
+
+	if len(runs[0]) == 0 {
+		t.Fatal("enrichProducts() created no variants or reviews to compare")
+	}
+	for i, run := range runs[1:] {
+		if !reflect.DeepEqual(run, runs[0]) {
+			t.Errorf("run %d created %v, want the same as the sequential run %v", i+1, run, runs[0])
+		}
+	}
+}
+
+func TestEnrichProductsCompletesThroughRateLimits(t *testing.T) {
+	store := &enrichmentStore{t: t, created: make(map[int][]string), deleted: make(map[int][]string), rateLimitEvery: 7, rateLimited: make(map[string]bool)}
+	client := newTestClient(t, store, WithRetry(3))
+
+	productIDs := make([]int, 20)
+	for i := range productIDs {
+		productIDs[i] = 1000 + i
+	}
+
+	cfg := enrichmentConfig(4)
+	checkpoint := NewCheckpoint("", cfg.Seed)
+	results := enrichProducts(t.Context(), client, cfg, checkpoint, nil, newSKUPool(), productIDs)
+
+	limited := 0
+	for _, wasLimited := range store.rateLimited {
+		if wasLimited {
+			limited++
+		}
+	}
+	if limited == 0 {
+		t.Fatal("the store rate limited no requests")
+	}
+	if incomplete := summarizeResults(results); incomplete != "" {
+		t.Errorf("enrichProducts() left products incomplete after %d 429s: %s", limited, incomplete)
+	}
+	for _, productID := range productIDs {
+		if !checkpoint.IsEnriched(productID) {
+			t.Errorf("product %d is not recorded as enriched", productID)
+		}
+	}
+}
+
+func TestSKUPoolNextIsUnique(t *testing.T) {
+	skus := newSKUPool()
+	seen := make(map[string]bool)
+	take := func(sku string) {
+		if seen[sku] {
+			t.Fatalf("Next() returned %s twice", sku)
+		}
+		seen[sku] = true
+	}
+
+	// Product SKUs come from the shared generator and variant SKUs from
+	// each product's own, seeded alike so their draws would collide
+	gofakeit.Seed(1)
+	for i := 0; i < 1000; i++ {
+		take(skus.Next(gofakeit.GlobalFaker))
+	}
+	for i := 0; i < 100; i++ {
+		faker := gofakeit.New(1)
+		for j := 0; j < 10; j++ {
+			take(skus.Next(faker))
+		}
+	}
+}
+
+func TestEnrichProductsClearsPartlyEnrichedProducts(t *testing.T) {
+	store := &enrichmentStore{t: t, created: make(map[int][]string), deleted: make(map[int][]string), leftImages: map[int]bool{1001: true}}
+	client := newTestClient(t, store)
+
+	cfg := enrichmentConfig(2)
+	checkpoint := NewCheckpoint("", cfg.Seed)
+	checkpoint.RecordStarted(1001)
+	checkpoint.RecordStarted(1002)
+	checkpoint.RecordEnriched(1002)
+
+	results := enrichProducts(t.Context(), client, cfg, checkpoint, nil, newSKUPool(), []int{1000, 1001, 1002})
+	if incomplete := summarizeResults(results); incomplete != "" {
+		t.Fatalf("enrichProducts() left products incomplete: %s", incomplete)
+	}
+
+	if !slices.Contains(store.deleted[1001], "images/7") || !slices.Contains(store.deleted[1001], "bulk-pricing-rules") {
+		t.Errorf("partly enriched product had %v deleted, want its image and bulk pricing rules", store.deleted[1001])
+	}
+	for _, productID := range []int{1000, 1002} {
+		if len(store.deleted[productID]) > 0 {
+			t.Errorf("product %d had %v deleted", productID, store.deleted[productID])
+		}
+	}
+	if len(store.created[1002]) > 0 {
+		t.Errorf("enriched product 1002 was enriched again")
+	}
+	for _, productID := range []int{1000, 1001, 1002} {
+		if !checkpoint.IsEnriched(productID) {
+			t.Errorf("product %d is not recorded as enriched", productID)
+		}
+	}
+}
+
+func TestGenerateProductsIgnoresTaxClassLookup(t *testing.T) {
+	generate := func(taxClassIDs []int) []Product {
+		gofakeit.Seed(7)
+		cfg := &Config{DescriptionParagraphs: 1}
+		return generateProducts(cfg, newSlugger(cfg), newSKUPool(), []int{1, 2, 3}, []int{4, 5}, taxClassIDs, nil)
+	}
+
+	without, with := generate(nil), generate([]int{8, 9})
+	for i := range without {
+		if without[i].Name != with[i].Name || without[i].SKU != with[i].SKU {
+			t.Errorf("product %d is %s (%s) without tax classes, %s (%s) with them", i, without[i].Name, without[i].SKU, with[i].Name, with[i].SKU)
+		}
+		if with[i].TaxClassID != 8 && with[i].TaxClassID != 9 {
+			t.Errorf("product %d has tax class %d, want 8 or 9", i, with[i].TaxClassID)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Manifest lists the identifiers of what a run produced, for scripts that
+// drive follow-up tests against the store. Categories and brands reused
+// with -skip-existing are listed too, as generated products reference
+// them; products are listed when this run or a checkpointed one created
+// them.
+type Manifest struct {
+	Seed         int64           `json:"seed"`
+	Channel      int             `json:"channel,omitempty"`
+	Categories   []ManifestEntry `json:"categories"`
+	Brands       []ManifestEntry `json:"brands"`
+	Products     []ManifestEntry `json:"products"`
+	GiftWrapping []int           `json:"gift_wrapping,omitempty"`
+}
+
+// ManifestEntry identifies one entity. URL is only known with -custom-urls.
+// Created holds the IDs of a product's sub-resources by enrichment step.
+type ManifestEntry struct {
+	ID      int              `json:"id"`
+	Name    string           `json:"name"`
+	SKU     string           `json:"sku,omitempty"`
+	URL     string           `json:"url,omitempty"`
+	Created map[string][]int `json:"created,omitempty"`
+}
+
+func newManifest(cfg *Config, checkpoint *Checkpoint, categories []Category, categoryIDs []int, brands []Brand, brandIDs []int, products []Product, results []ProductResult) *Manifest {
+	manifest := &Manifest{
+		Seed:       cfg.Seed,
+		Categories: make([]ManifestEntry, 0, len(categories)),
+		Brands:     make([]ManifestEntry, 0, len(brands)),
+		Products:   make([]ManifestEntry, 0, len(products)),
+	}
+
+	for i, category := range categories {
+		if categoryIDs[i] != 0 {
+			manifest.Categories = append(manifest.Categories, ManifestEntry{ID: categoryIDs[i], Name: category.Name, URL: customURLPath(category.CustomURL)})
+		}
+	}
+	for i, brand := range brands {
+		if brandIDs[i] != 0 {
+			manifest.Brands = append(manifest.Brands, ManifestEntry{ID: brandIDs[i], Name: brand.Name, URL: customURLPath(brand.CustomURL)})
+		}
+	}
+
+	created := make(map[int]map[string][]int, len(results))
+	for _, result := range results {
+		if len(result.Created) > 0 {
+			created[result.ProductID] = result.Created
+		}
+	}
+	for _, product := range products {
+		if productID, ok := checkpoint.Product(product.SKU); ok {
+			manifest.Products = append(manifest.Products, ManifestEntry{
+				ID:      productID,
+				Name:    product.Name,
+				SKU:     product.SKU,
+				URL:     customURLPath(product.CustomURL),
+				Created: created[productID],
+			})
+		}
+	}
+
+	return manifest
+}
+
+func writeManifest(path string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	return nil
+}
+
+func customURLPath(customURL *CustomURL) string {
+	if customURL == nil {
+		return ""
+	}
+	return customURL.URL
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// skuPool hands out SKUs that are unique across the run. The API rejects a
+// SKU used anywhere in the store, by a product or a variant, so both draw
+// from the same pool. It is safe for concurrent use by enrichment workers.
+type skuPool struct {
+	mu    sync.Mutex
+	taken map[string]bool
+}
+
+func newSKUPool() *skuPool {
+	return &skuPool{taken: make(map[string]bool)}
+}
+
+// Next returns a SKU drawn from faker that no earlier call has returned,
+// redrawing on the unlikely collision so the seeded sequence stays
+// reproducible.
+func (p *skuPool) Next(faker *gofakeit.Faker) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for {
+		sku := faker.UUID()
+		if !p.taken[sku] {
+			p.taken[sku] = true
+			return sku
+		}
+	}
+}
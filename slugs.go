@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// slugTransliterations spells out the accented letters the de and fr
+// locales produce, so their slugs stay readable ASCII.
+var slugTransliterations = strings.NewReplacer(
+	"ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss",
+	"à", "a", "â", "a", "æ", "ae", "ç", "c",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "ô", "o", "œ", "oe",
+	"ù", "u", "û", "u", "ÿ", "y",
+)
+
+// slugger hands out custom URLs derived from names. Products, categories
+// and brands share the storefront's URL space, so one slugger is used for
+// the whole run and repeated names get a numeric suffix. A nil slugger
+// leaves custom URLs unset so the store generates its own.
+type slugger struct {
+	taken map[string]bool
+}
+
+func newSlugger(cfg *Config) *slugger {
+	if !cfg.CustomURLs {
+		return nil
+	}
+	return &slugger{taken: make(map[string]bool)}
+}
+
+// CustomURL returns a unique "/slug/" URL for name, or nil when custom URLs
+// are disabled.
+func (s *slugger) CustomURL(name string) *CustomURL {
+	if s == nil {
+		return nil
+	}
+
+	base := slugify(name)
+	slug := base
+	for n := 2; s.taken[slug]; n++ {
+		slug = base + "-" + strconv.Itoa(n)
+	}
+	s.taken[slug] = true
+
+	return &CustomURL{URL: "/" + slug + "/", IsCustomized: true}
+}
+
+// slugify lowercases name and joins its runs of letters and digits with
+// hyphens, e.g. "Ergonomic Steel Chair" becomes "ergonomic-steel-chair".
+func slugify(name string) string {
+	name = slugTransliterations.Replace(strings.ToLower(name))
+
+	var b strings.Builder
+	hyphen := false
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+			continue
+		}
+		hyphen = true
+	}
+
+	if b.Len() == 0 {
+		return "item"
+	}
+	return b.String()
+}
@@ -0,0 +1,154 @@
+// Command contextfree writes contextfree.go: for every exported
+// ...Context method on a service in bigcommerce.go, a wrapper without the
+// suffix that passes context.Background(). Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	source = "bigcommerce.go"
+	output = "contextfree.go"
+)
+
+func main() {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, source, nil, 0)
+	if err != nil {
+		log.Fatalf("failed to parse %s: %v", source, err)
+	}
+
+	var body bytes.Buffer
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || !isServiceMethod(fn) {
+			continue
+		}
+		writeWrapper(&body, fset, fn)
+	}
+
+	// Import whatever the copied signatures refer to, plus context
+	imports := []string{"context"}
+	for _, spec := range file.Imports {
+		path := strings.Trim(spec.Path.Value, `"`)
+		pkg := path[strings.LastIndex(path, "/")+1:]
+		if pkg != "context" && regexp.MustCompile(`\b`+pkg+`\.`).Match(body.Bytes()) {
+			imports = append(imports, path)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by tools/contextfree; DO NOT EDIT.\n\n")
+	buf.WriteString("package main\n\nimport (\n")
+	for _, path := range imports {
+		buf.WriteString("\t\"" + path + "\"\n")
+	}
+	buf.WriteString(")\n")
+	buf.Write(body.Bytes())
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format %s: %v", output, err)
+	}
+	if err := os.WriteFile(output, formatted, 0o644); err != nil {
+		log.Fatalf("failed to write %s: %v", output, err)
+	}
+}
+
+// isServiceMethod reports whether fn is an exported ...Context method on a
+// *...Service taking ctx first.
+func isServiceMethod(fn *ast.FuncDecl) bool {
+	if fn.Recv == nil || !fn.Name.IsExported() || !strings.HasSuffix(fn.Name.Name, "Context") {
+		return false
+	}
+
+	star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	recv, ok := star.X.(*ast.Ident)
+	if !ok || !strings.HasSuffix(recv.Name, "Service") {
+		return false
+	}
+
+	params := fn.Type.Params.List
+	if len(params) == 0 {
+		return false
+	}
+	sel, ok := params[0].Type.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Context"
+}
+
+func writeWrapper(buf *bytes.Buffer, fset *token.FileSet, fn *ast.FuncDecl) {
+	recv := fn.Recv.List[0]
+	recvName := recv.Names[0].Name
+	name := strings.TrimSuffix(fn.Name.Name, "Context")
+
+	var params, args []string
+	for i, field := range fn.Type.Params.List {
+		for j, ident := range field.Names {
+			if i == 0 && j == 0 {
+				continue // ctx
+			}
+			arg := ident.Name
+			if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+				arg += "..."
+			}
+			args = append(args, arg)
+		}
+		if i == 0 {
+			if len(field.Names) > 1 {
+				params = append(params, strings.Join(names(field.Names[1:]), ", ")+" "+expr(fset, field.Type))
+			}
+			continue
+		}
+		params = append(params, strings.Join(names(field.Names), ", ")+" "+expr(fset, field.Type))
+	}
+
+	results := ""
+	if fn.Type.Results != nil {
+		var types []string
+		for _, field := range fn.Type.Results.List {
+			types = append(types, expr(fset, field.Type))
+		}
+		results = " " + strings.Join(types, ", ")
+		if len(types) > 1 {
+			results = " (" + results[1:] + ")"
+		}
+	}
+
+	call := recvName + "." + fn.Name.Name + "(" + strings.Join(append([]string{"context.Background()"}, args...), ", ") + ")"
+	if fn.Type.Results != nil {
+		call = "return " + call
+	}
+
+	buf.WriteString("\n// " + name + " calls " + fn.Name.Name + " with context.Background().\n")
+	buf.WriteString("func (" + recvName + " " + expr(fset, recv.Type) + ") " + name + "(" + strings.Join(params, ", ") + ")" + results + " {\n")
+	buf.WriteString("\t" + call + "\n}\n")
+}
+
+func names(idents []*ast.Ident) []string {
+	out := make([]string, len(idents))
+	for i, ident := range idents {
+		out[i] = ident.Name
+	}
+	return out
+}
+
+func expr(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, node); err != nil {
+		log.Fatalf("failed to print node: %v", err)
+	}
+	return buf.String()
+}